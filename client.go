@@ -27,11 +27,15 @@ const (
 
 // Client for DNS API.
 type Client struct {
-	HTTPClient *http.Client
-	UserAgent  string
-	BaseURL    *url.URL
-	authHeader func() string
-	Debug      bool
+	HTTPClient  *http.Client
+	UserAgent   string
+	BaseURL     *url.URL
+	authHeader  func() string
+	Debug       bool
+	cache       *responseCache
+	dryRun      *dryRunLog
+	credentials CredentialsProvider
+	clientID    uint64
 }
 
 // ZonesFilter find zones
@@ -66,7 +70,12 @@ func (zf ZonesFilter) query() string {
 func NewClient(authorizer func() authHeader, opts ...func(*Client)) *Client {
 	baseURL, _ := url.Parse(defaultBaseURL)
 	cl := &Client{
-		authHeader: func() string { return string(authorizer()) },
+		authHeader: func() string {
+			if authorizer == nil {
+				return ""
+			}
+			return string(authorizer())
+		},
 		BaseURL:    baseURL,
 		HTTPClient: &http.Client{Timeout: defaultTimeOut},
 	}
@@ -423,6 +432,21 @@ func (c *Client) ZoneNameservers(ctx context.Context, name string) ([]string, er
 	return resp, nil
 }
 
+// ZoneRRSets gets all RRSets of zone.
+// https://apidocs.gcore.com/dns#tag/rrsets/operation/RRSets
+func (c *Client) ZoneRRSets(ctx context.Context, zone string) ([]ZoneRRSet, error) {
+	zone = strings.Trim(zone, ".")
+	uri := fmt.Sprintf("/v2/zones/%s/rrsets?all=true", zone)
+
+	var res ZoneRRSets
+	err := c.do(ctx, http.MethodGet, uri, nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("get rrsets %s: %w", zone, err)
+	}
+
+	return res.RRSets, nil
+}
+
 // RRSet gets RRSet item.
 // https://apidocs.gcore.com/dns#tag/rrsets/operation/RRSet
 func (c *Client) RRSet(ctx context.Context, zone, name, recordType string, limit, offset int) (RRSet, error) {
@@ -634,6 +658,162 @@ func (c *Client) ImportZone(ctx context.Context, name, content string) (ImportZo
 	return response, nil
 }
 
+// CreateSecondaryZone adds new secondary zone transferred from primary servers.
+func (c *Client) CreateSecondaryZone(ctx context.Context, zone AddSecondaryZone) (uint64, error) {
+	if err := zone.Validate(); err != nil {
+		return 0, fmt.Errorf("validate: %w", err)
+	}
+	res := CreateResponse{}
+	err := c.do(ctx, http.MethodPost, "/v2/secondary-zones", zone, &res)
+	if err != nil {
+		return 0, fmt.Errorf("request: %w", err)
+	}
+	if res.Error != "" {
+		return 0, APIError{StatusCode: http.StatusOK, Message: res.Error}
+	}
+
+	return res.ID, nil
+}
+
+// UpdateSecondaryZone replaces primary servers and notify settings of secondary zone.
+func (c *Client) UpdateSecondaryZone(ctx context.Context, name string, zone AddSecondaryZone) error {
+	name = strings.Trim(name, ".")
+	if zone.Name == "" {
+		zone.Name = name
+	}
+	if err := zone.Validate(); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+	uri := path.Join("/v2/secondary-zones", name)
+
+	err := c.do(ctx, http.MethodPut, uri, zone, nil)
+	if err != nil {
+		return fmt.Errorf("update secondary zone %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// SecondaryZone gets secondary zone information.
+func (c *Client) SecondaryZone(ctx context.Context, name string) (SecondaryZone, error) {
+	name = strings.Trim(name, ".")
+	uri := path.Join("/v2/secondary-zones", name)
+
+	var zone SecondaryZone
+	err := c.do(ctx, http.MethodGet, uri, nil, &zone)
+	if err != nil {
+		return SecondaryZone{}, fmt.Errorf("get secondary zone %s: %w", name, err)
+	}
+	if err = zone.Validate(); err != nil {
+		return SecondaryZone{}, fmt.Errorf("get secondary zone %s: %w", name, err)
+	}
+
+	return zone, nil
+}
+
+// DeleteSecondaryZone removes secondary zone.
+func (c *Client) DeleteSecondaryZone(ctx context.Context, name string) error {
+	name = strings.Trim(name, ".")
+	uri := path.Join("/v2/secondary-zones", name)
+
+	err := c.do(ctx, http.MethodDelete, uri, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete secondary zone %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// SecondaryZoneTransfer gets status of the last transfer from primary servers.
+func (c *Client) SecondaryZoneTransfer(ctx context.Context, name string) (TransferStatus, error) {
+	name = strings.Trim(name, ".")
+	uri := path.Join("/v2/secondary-zones", name, "transfer")
+
+	var status TransferStatus
+	err := c.do(ctx, http.MethodGet, uri, nil, &status)
+	if err != nil {
+		return TransferStatus{}, fmt.Errorf("get transfer %s: %w", name, err)
+	}
+
+	return status, nil
+}
+
+// RetransferSecondaryZone asks to transfer zone from primary servers now.
+func (c *Client) RetransferSecondaryZone(ctx context.Context, name string) error {
+	name = strings.Trim(name, ".")
+	uri := path.Join("/v2/secondary-zones", name, "transfer")
+
+	err := c.do(ctx, http.MethodPost, uri, nil, nil)
+	if err != nil {
+		return fmt.Errorf("retransfer %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// TSIGKeys lists TSIG keys, secrets are not returned.
+// Keys with algorithm unknown to SDK are listed too, check them with TSIGKey.Known.
+func (c *Client) TSIGKeys(ctx context.Context) ([]TSIGKey, error) {
+	var res ListTSIGKeys
+	err := c.do(ctx, http.MethodGet, "/v2/tsig-keys", nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+
+	return res.TSIGKeys, nil
+}
+
+// CreateTSIGKey adds new TSIG key to be referenced by primary servers.
+func (c *Client) CreateTSIGKey(ctx context.Context, key TSIGKey) (uint64, error) {
+	key = key.normalized()
+	if err := key.Validate(); err != nil {
+		return 0, fmt.Errorf("validate: %w", err)
+	}
+	res := CreateResponse{}
+	err := c.do(ctx, http.MethodPost, "/v2/tsig-keys", key, &res)
+	if err != nil {
+		return 0, fmt.Errorf("request: %w", err)
+	}
+	if res.Error != "" {
+		return 0, APIError{StatusCode: http.StatusOK, Message: res.Error}
+	}
+
+	return res.ID, nil
+}
+
+// UpdateTSIGKey changes algorithm or rotates secret of TSIG key.
+func (c *Client) UpdateTSIGKey(ctx context.Context, name string, key TSIGKey) error {
+	name = strings.Trim(name, ".")
+	if key.Name == "" {
+		key.Name = name
+	}
+	key = key.normalized()
+	if err := key.Validate(); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+	uri := path.Join("/v2/tsig-keys", url.PathEscape(name))
+
+	err := c.do(ctx, http.MethodPut, uri, key, nil)
+	if err != nil {
+		return fmt.Errorf("update tsig key %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteTSIGKey removes TSIG key.
+func (c *Client) DeleteTSIGKey(ctx context.Context, name string) error {
+	name = strings.Trim(name, ".")
+	uri := path.Join("/v2/tsig-keys", url.PathEscape(name))
+
+	err := c.do(ctx, http.MethodDelete, uri, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete tsig key %s: %w", name, err)
+	}
+
+	return nil
+}
+
 type NetworkMappingsParams struct {
 	Offset         uint64
 	Limit          uint64
@@ -750,25 +930,53 @@ func (c *Client) do(ctx context.Context, method, uri string, bodyParams interfac
 	if err != nil {
 		return fmt.Errorf("failed to parse endpoint: %w", err)
 	}
+	if c.clientID != 0 {
+		query := endpoint.Query()
+		query.Set("client_id", strconv.FormatUint(c.clientID, 10))
+		endpoint.RawQuery = query.Encode()
+	}
 
-	if c.Debug {
-		log.Printf("[DEBUG] dns api request: %s %s %s \n", method, uri, bs)
+	if c.dryRun != nil && method != http.MethodGet {
+		if c.Debug {
+			log.Printf("[DEBUG] dns api dry-run: %s %s %s \n", method, uri, bs)
+		}
+		c.dryRun.record(method, uri, bs, dest)
+		return nil
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), strings.NewReader(string(bs)))
-	if err != nil {
-		return fmt.Errorf("new request: %w", err)
+	var cacheGen uint64
+	if c.cache != nil {
+		if method == http.MethodGet {
+			var cached []byte
+			var ok bool
+			if cached, cacheGen, ok = c.cache.get(uri); ok {
+				if c.Debug {
+					log.Printf("[DEBUG] dns api cached: %s %s \n", method, uri)
+				}
+				return decodeBody(cached, dest)
+			}
+		} else {
+			// state is unknown after any write attempt
+			defer c.cache.invalidate(uri)
+		}
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.authHeader())
-	if c.UserAgent != "" {
-		req.Header.Set("User-Agent", c.UserAgent)
+	if c.Debug {
+		log.Printf("[DEBUG] dns api request: %s %s %s \n", method, uri, bs)
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(ctx, method, endpoint.String(), bs)
 	if err != nil {
-		return fmt.Errorf("send request: %w", err)
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.credentials != nil {
+		// credentials could be rotated or expired, re-auth once
+		_ = resp.Body.Close()
+		c.credentials.Invalidate()
+		resp, err = c.send(ctx, method, endpoint.String(), bs)
+		if err != nil {
+			return err
+		}
 	}
 
 	defer func() { _ = resp.Body.Close() }()
@@ -791,6 +999,42 @@ func (c *Client) do(ctx context.Context, method, uri string, bodyParams interfac
 		return fmt.Errorf("read response body: %w", err)
 	}
 
+	if c.cache != nil && method == http.MethodGet {
+		c.cache.set(uri, body, cacheGen)
+	}
+
+	return decodeBody(body, dest)
+}
+
+func (c *Client) send(ctx context.Context, method, endpoint string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	auth := c.authHeader()
+	if c.credentials != nil {
+		auth, err = c.credentials.AuthHeader(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("credentials: %w", err)
+		}
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", auth)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+
+	return resp, nil
+}
+
+func decodeBody(body []byte, dest interface{}) error {
 	if dest == nil {
 		return nil
 	}
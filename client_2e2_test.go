@@ -2,14 +2,18 @@ package dnssdk
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/G-Core/gcore-dns-sdk-go/dnstest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
@@ -22,14 +26,7 @@ const defaultTTL = 120
 var defaultNS = []string{"ns1.gcorelabs.net", "ns2.gcdn.services"}
 
 func TestE2E_ZonesWithRRSets(t *testing.T) {
-	apiToken := strings.TrimSpace(os.Getenv("TESTS_API_PERMANENT_TOKEN"))
-	if apiToken == "" {
-		t.Skip("no defined TESTS_API_PERMANENT_TOKEN")
-	}
-
-	sdk := NewClient(PermanentAPIKeyAuth(apiToken), func(client *Client) {
-		client.Debug = true
-	})
+	sdk, randStr := e2eClient(t)
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -183,14 +180,7 @@ func TestE2E_ZonesWithRRSets(t *testing.T) {
 }
 
 func TestClientE2E_ZoneNameservers(t *testing.T) {
-	apiToken := strings.TrimSpace(os.Getenv("TESTS_API_PERMANENT_TOKEN"))
-	if apiToken == "" {
-		t.Skip("no defined TESTS_API_PERMANENT_TOKEN")
-	}
-
-	sdk := NewClient(PermanentAPIKeyAuth(apiToken), func(client *Client) {
-		client.Debug = true
-	})
+	sdk, randStr := e2eClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -216,11 +206,13 @@ func TestClientE2E_ZoneNameservers(t *testing.T) {
 
 	for _, z := range expZones {
 		zone := z
+		// random name is taken before goroutine to be the same on replay
+		recName := randStr() + "." + zoneName
 		group.Go(func() error {
 			rr := ResourceRecord{}
 			rr.SetContent(nsRecordType, zone)
 
-			return sdk.AddZoneRRSet(ctxGroup, zoneName, randStr()+"."+zoneName, nsRecordType, []ResourceRecord{rr},
+			return sdk.AddZoneRRSet(ctxGroup, zoneName, recName, nsRecordType, []ResourceRecord{rr},
 				defaultTTL, defaultFilterOpts())
 		})
 	}
@@ -255,14 +247,7 @@ func TestClientE2E_ZoneNameservers(t *testing.T) {
 }
 
 func TestClientE2E_ZoneWithDNSSEC(t *testing.T) {
-	apiToken := strings.TrimSpace(os.Getenv("TESTS_API_PERMANENT_TOKEN"))
-	if apiToken == "" {
-		t.Skip("no defined TESTS_API_PERMANENT_TOKEN")
-	}
-
-	sdk := NewClient(PermanentAPIKeyAuth(apiToken), func(client *Client) {
-		client.Debug = true
-	})
+	sdk, randStr := e2eClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
@@ -304,6 +289,49 @@ func defaultFilterOpts() AddZoneOpt {
 	return WithFilters(NewDefaultFilter(1, true))
 }
 
+// e2eClient to live API when TESTS_API_PERMANENT_TOKEN is defined, recording cassette also with TESTS_RECORD.
+// Without token cassette recorded from real account is replayed offline, test is skipped when it is missing.
+// Returned randStr registers random values in cassette to record them as placeholders.
+func e2eClient(t *testing.T) (*Client, func() string) {
+	t.Helper()
+
+	cassettePath := filepath.Join("testdata", "cassettes", t.Name()+".json")
+	apiToken := strings.TrimSpace(os.Getenv("TESTS_API_PERMANENT_TOKEN"))
+	var cassette *dnstest.Cassette
+	switch {
+	case apiToken != "" && os.Getenv("TESTS_RECORD") != "":
+		cassette = dnstest.NewRecorder(cassettePath, nil)
+		t.Cleanup(func() {
+			if !t.Failed() {
+				require.NoError(t, cassette.Save(), "save cassette")
+			}
+		})
+	case apiToken == "":
+		var err error
+		cassette, err = dnstest.NewReplayer(cassettePath)
+		if errors.Is(err, os.ErrNotExist) {
+			t.Skip("no defined TESTS_API_PERMANENT_TOKEN and no recorded cassette")
+		}
+		require.NoError(t, err, "load cassette")
+		apiToken = "replay"
+	}
+
+	sdk := NewClient(PermanentAPIKeyAuth(apiToken), func(client *Client) {
+		if cassette != nil {
+			client.HTTPClient.Transport = cassette
+		}
+	})
+
+	var n uint64
+	return sdk, func() string {
+		s := randStr()
+		if cassette != nil {
+			cassette.Normalize(s, fmt.Sprintf("rand%06d", atomic.AddUint64(&n, 1)))
+		}
+		return s
+	}
+}
+
 var letterRunes = []rune("abcdefghijklmnopqrstuvwxyz")
 
 func randStr() string {
@@ -0,0 +1,77 @@
+package dnssdk
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=client_api.go -destination=dnsmock/mock.go -package=dnsmock -imports=dnssdk=github.com/G-Core/gcore-dns-sdk-go
+
+// ZonesAPI zones methods of Client
+type ZonesAPI interface {
+	CreateZone(ctx context.Context, addZone AddZone) (uint64, error)
+	UpdateZone(ctx context.Context, name string, updateZone AddZone) (uint64, error)
+	Zones(ctx context.Context, filters ...func(zone *ZonesFilter)) ([]Zone, error)
+	ZonesWithParam(ctx context.Context, param ZonesParam) (ListZones, error)
+	AllZones(ctx context.Context, nameFilters []string) ([]Zone, error)
+	ZonesWithRecords(ctx context.Context, filters ...func(zone *ZonesFilter)) ([]Zone, error)
+	AllZonesWithRecords(ctx context.Context, nameFilters []string) ([]Zone, error)
+	DeleteZone(ctx context.Context, name string) error
+	Zone(ctx context.Context, name string) (Zone, error)
+	ZoneNameservers(ctx context.Context, name string) ([]string, error)
+	EnableZone(ctx context.Context, name string) error
+	DisableZone(ctx context.Context, name string) error
+	ImportZone(ctx context.Context, name, content string) (ImportZoneResponse, error)
+}
+
+// RRSetsAPI rrsets methods of Client
+type RRSetsAPI interface {
+	ZoneRRSets(ctx context.Context, zone string) ([]ZoneRRSet, error)
+	RRSet(ctx context.Context, zone, name, recordType string, limit, offset int) (RRSet, error)
+	CreateRRSet(ctx context.Context, zone, name, recordType string, record RRSet) error
+	UpdateRRSet(ctx context.Context, zone, name, recordType string, record RRSet) error
+	DeleteRRSet(ctx context.Context, zone, name, recordType string) error
+	DeleteRRSetRecord(ctx context.Context, zone, name, recordType string, contents ...string) error
+	AddZoneRRSet(ctx context.Context, zone, recordName, recordType string,
+		values []ResourceRecord, ttl int, opts ...AddZoneOpt) error
+}
+
+// DNSSECAPI dnssec methods of Client
+type DNSSECAPI interface {
+	DNSSecDS(ctx context.Context, zone string) (DNSSecDS, error)
+	ToggleDnssec(ctx context.Context, zone string, enable bool) (DNSSecDS, error)
+}
+
+// NetworkMappingsAPI network mappings methods of Client
+type NetworkMappingsAPI interface {
+	ListNetworkMappings(ctx context.Context, params NetworkMappingsParams) (*ListNetworkMappingResponse, error)
+	CreateNetworkMapping(ctx context.Context, mapping NetworkMappingRequest) (uint64, error)
+	GetNetworkMapping(ctx context.Context, id uint64) (*NetworkMappingResponse, error)
+	GetNetworkMappingByName(ctx context.Context, name string) (*NetworkMappingResponse, error)
+	UpdateNetworkMapping(ctx context.Context, id uint64, mapping NetworkMappingRequest) error
+	DeleteNetworkMapping(ctx context.Context, id uint64) error
+}
+
+// SecondaryZonesAPI secondary zones and tsig keys methods of Client
+type SecondaryZonesAPI interface {
+	CreateSecondaryZone(ctx context.Context, zone AddSecondaryZone) (uint64, error)
+	UpdateSecondaryZone(ctx context.Context, name string, zone AddSecondaryZone) error
+	SecondaryZone(ctx context.Context, name string) (SecondaryZone, error)
+	DeleteSecondaryZone(ctx context.Context, name string) error
+	SecondaryZoneTransfer(ctx context.Context, name string) (TransferStatus, error)
+	RetransferSecondaryZone(ctx context.Context, name string) error
+	TSIGKeys(ctx context.Context) ([]TSIGKey, error)
+	CreateTSIGKey(ctx context.Context, key TSIGKey) (uint64, error)
+	UpdateTSIGKey(ctx context.Context, name string, key TSIGKey) error
+	DeleteTSIGKey(ctx context.Context, name string) error
+}
+
+// API all methods of Client
+type API interface {
+	ZonesAPI
+	RRSetsAPI
+	DNSSECAPI
+	NetworkMappingsAPI
+	SecondaryZonesAPI
+}
+
+var _ API = (*Client)(nil)
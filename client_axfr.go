@@ -0,0 +1,496 @@
+package dnssdk
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"  // nolint: gosec
+	"crypto/sha1" // nolint: gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const defaultAXFRTimeout = 30 * time.Second
+
+// AXFRSource describe authoritative server to transfer zone from
+type AXFRSource struct {
+	// Server host or host:port, 53 port is used when omitted
+	Server string
+	Zone   string
+	// TSIG signs transfer request, optional
+	TSIG *TSIGKey
+	// Timeout of reading every transfer message, 30s when empty
+	Timeout time.Duration
+	// KeepApexNS migrates NS records of zone apex, they are managed by G-Core otherwise
+	KeepApexNS bool
+}
+
+// TransferredRRSet RRSet received by AXFR with its owner name
+type TransferredRRSet struct {
+	Name  string
+	RRSet RRSet
+}
+
+// ZoneTransfer result of AXFR converted to RRSets
+type ZoneTransfer struct {
+	Zone    string
+	Serial  uint32
+	RRSets  []TransferredRRSet
+	Skipped []SkippedRecord
+
+	records []dns.RR
+}
+
+// SkippedRecord not migrated record with the reason
+type SkippedRecord struct {
+	Record string
+	Reason string
+}
+
+// types signed and maintained by G-Core itself
+var axfrSkipTypes = map[uint16]string{
+	dns.TypeSOA:        "soa is managed by zone settings",
+	dns.TypeRRSIG:      "dnssec is managed by ToggleDnssec",
+	dns.TypeNSEC:       "dnssec is managed by ToggleDnssec",
+	dns.TypeNSEC3:      "dnssec is managed by ToggleDnssec",
+	dns.TypeNSEC3PARAM: "dnssec is managed by ToggleDnssec",
+	dns.TypeDNSKEY:     "dnssec is managed by ToggleDnssec",
+	dns.TypeCDS:        "dnssec is managed by ToggleDnssec",
+	dns.TypeCDNSKEY:    "dnssec is managed by ToggleDnssec",
+}
+
+// tsigAlgorithms wire names of TSIG algorithms
+var tsigAlgorithms = map[string]string{
+	TSIGAlgorithmHMACMD5:    dns.HmacMD5,
+	TSIGAlgorithmHMACSHA1:   dns.HmacSHA1,
+	TSIGAlgorithmHMACSHA224: dns.HmacSHA224,
+	TSIGAlgorithmHMACSHA256: dns.HmacSHA256,
+	TSIGAlgorithmHMACSHA384: dns.HmacSHA384,
+	TSIGAlgorithmHMACSHA512: dns.HmacSHA512,
+}
+
+// tsigHMAC TsigProvider of single key, dns package dropped hmac-md5 which is still used by old primaries
+type tsigHMAC struct {
+	name   string
+	secret []byte
+}
+
+// Generate implementation of dns.TsigProvider
+func (k tsigHMAC) Generate(msg []byte, t *dns.TSIG) ([]byte, error) {
+	if dns.CanonicalName(t.Hdr.Name) != k.name {
+		return nil, dns.ErrSecret
+	}
+	var h func() hash.Hash
+	switch dns.CanonicalName(t.Algorithm) {
+	case dns.HmacMD5:
+		h = md5.New
+	case dns.HmacSHA1:
+		h = sha1.New
+	case dns.HmacSHA224:
+		h = sha256.New224
+	case dns.HmacSHA256:
+		h = sha256.New
+	case dns.HmacSHA384:
+		h = sha512.New384
+	case dns.HmacSHA512:
+		h = sha512.New
+	default:
+		return nil, dns.ErrKeyAlg
+	}
+	mac := hmac.New(h, k.secret)
+	mac.Write(msg)
+	return mac.Sum(nil), nil
+}
+
+// Verify implementation of dns.TsigProvider
+func (k tsigHMAC) Verify(msg []byte, t *dns.TSIG) error {
+	expected, err := k.Generate(msg, t)
+	if err != nil {
+		return err
+	}
+	got, err := hex.DecodeString(t.MAC)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(got, expected) {
+		return dns.ErrSig
+	}
+	return nil
+}
+
+// TransferZone does AXFR from src and groups records into RRSets.
+func TransferZone(ctx context.Context, src AXFRSource) (ZoneTransfer, error) {
+	zone := dns.Fqdn(strings.ToLower(strings.Trim(src.Zone, ".")))
+	server := src.Server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, fmt.Sprint(defaultDNSPort))
+	}
+	timeout := src.Timeout
+	if timeout == 0 {
+		timeout = defaultAXFRTimeout
+	}
+
+	conn, err := (&net.Dialer{Timeout: timeout}).DialContext(ctx, "tcp", server)
+	if err != nil {
+		return ZoneTransfer{}, fmt.Errorf("dial %s: %w", server, err)
+	}
+	tr := &dns.Transfer{Conn: &dns.Conn{Conn: conn}, ReadTimeout: timeout, WriteTimeout: timeout}
+	msg := new(dns.Msg)
+	msg.SetAxfr(zone)
+	if src.TSIG != nil {
+		if err = src.TSIG.Validate(); err != nil {
+			_ = conn.Close()
+			return ZoneTransfer{}, fmt.Errorf("tsig: %w", err)
+		}
+		key := src.TSIG.normalized()
+		keyName := dns.Fqdn(strings.ToLower(key.Name))
+		secret, _ := base64.StdEncoding.DecodeString(key.Secret)
+		tr.TsigProvider = tsigHMAC{name: keyName, secret: secret}
+		msg.SetTsig(keyName, tsigAlgorithms[key.Algorithm], 300, time.Now().Unix())
+	}
+
+	envelopes, err := tr.In(msg, server)
+	if err != nil {
+		_ = conn.Close()
+		return ZoneTransfer{}, fmt.Errorf("axfr %s: %w", zone, err)
+	}
+	// conn is closed by transfer itself, close it earlier on cancel
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+
+	var records []dns.RR
+	for env := range envelopes {
+		if env.Error != nil && err == nil {
+			err = env.Error
+		}
+		records = append(records, env.RR...)
+	}
+	if ctx.Err() != nil {
+		return ZoneTransfer{}, fmt.Errorf("axfr %s: %w", zone, ctx.Err())
+	}
+	if err != nil {
+		return ZoneTransfer{}, fmt.Errorf("axfr %s: %w", zone, err)
+	}
+
+	return newZoneTransfer(zone, records, src.KeepApexNS), nil
+}
+
+func newZoneTransfer(zone string, records []dns.RR, keepApexNS bool) ZoneTransfer {
+	res := ZoneTransfer{Zone: strings.TrimSuffix(zone, ".")}
+	index := map[string]int{}
+	for _, rr := range records {
+		hdr := rr.Header()
+		if soa, ok := rr.(*dns.SOA); ok && res.Serial == 0 {
+			res.Serial = soa.Serial
+		}
+		if reason, ok := axfrSkipTypes[hdr.Rrtype]; ok {
+			if hdr.Rrtype != dns.TypeSOA {
+				res.Skipped = append(res.Skipped, SkippedRecord{Record: rr.String(), Reason: reason})
+			}
+			continue
+		}
+		if hdr.Rrtype == dns.TypeNS && strings.EqualFold(hdr.Name, zone) && !keepApexNS {
+			res.Skipped = append(res.Skipped, SkippedRecord{Record: rr.String(), Reason: "apex ns is managed by G-Core"})
+			continue
+		}
+		rType := dns.TypeToString[hdr.Rrtype]
+		name := strings.ToLower(strings.TrimSuffix(hdr.Name, "."))
+		key := name + " " + rType
+		i, ok := index[key]
+		if !ok {
+			i = len(res.RRSets)
+			index[key] = i
+			res.RRSets = append(res.RRSets, TransferredRRSet{
+				Name:  name,
+				RRSet: RRSet{Type: rType, TTL: int(hdr.Ttl)},
+			})
+		}
+		set := &res.RRSets[i].RRSet
+		// RRSet has single ttl, the lowest one is kept as the safest
+		if int(hdr.Ttl) < set.TTL {
+			set.TTL = int(hdr.Ttl)
+		}
+		record := ResourceRecord{Enabled: true}
+		record.SetContent(rType, rrValue(rr))
+		set.Records = append(set.Records, record)
+		res.records = append(res.records, rr)
+	}
+	sort.SliceStable(res.RRSets, func(i, j int) bool {
+		if res.RRSets[i].Name != res.RRSets[j].Name {
+			return res.RRSets[i].Name < res.RRSets[j].Name
+		}
+		return res.RRSets[i].RRSet.Type < res.RRSets[j].RRSet.Type
+	})
+	return res
+}
+
+// ParseZoneFile reads zone file in RFC 1035 format and groups records into RRSets like TransferZone
+func ParseZoneFile(zone string, r io.Reader) (ZoneTransfer, error) {
+	origin := dns.Fqdn(strings.ToLower(strings.Trim(zone, ".")))
+	zp := dns.NewZoneParser(r, origin, "")
+	var records []dns.RR
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		records = append(records, rr)
+	}
+	if err := zp.Err(); err != nil {
+		return ZoneTransfer{}, fmt.Errorf("parse zone %s: %w", zone, err)
+	}
+
+	return newZoneTransfer(origin, records, true), nil
+}
+
+// Snapshot of transferred rrsets, e.g. to lint it or diff it with zone of API
+func (zt ZoneTransfer) Snapshot() Snapshot {
+	snap := Snapshot{
+		Version: SnapshotVersion,
+		Zone:    zt.Zone,
+		SOA:     SnapshotSOA{Serial: uint64(zt.Serial)},
+		RRSets:  make([]SnapshotRRSet, 0, len(zt.RRSets)),
+	}
+	for _, set := range zt.RRSets {
+		snap.RRSets = append(snap.RRSets, SnapshotRRSet{
+			Name:    set.Name,
+			Type:    set.RRSet.Type,
+			TTL:     set.RRSet.TTL,
+			Records: set.RRSet.Records,
+		})
+	}
+	return snap
+}
+
+// rrValue presentation of record data without header in format expected by ContentFromValue
+func rrValue(rr dns.RR) string {
+	switch v := rr.(type) {
+	case *dns.TXT:
+		return txtValue(v.Txt)
+	case *dns.SPF:
+		return txtValue(v.Txt)
+	}
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+// txtValue joins character-strings, they are kept escaped by dns package
+func txtValue(parts []string) string {
+	res := make([]string, len(parts))
+	for i, p := range parts {
+		unescaped, err := ParseTXT(`"` + p + `"`)
+		if err != nil {
+			unescaped = p
+		}
+		res[i] = unescaped
+	}
+	return JoinTXT(res)
+}
+
+// ZoneFile of transferred records, skipped records are not included
+func (zt ZoneTransfer) ZoneFile() string {
+	return zt.zoneFileFor(zt.Zone)
+}
+
+// zoneFileFor moves records into target zone
+func (zt ZoneTransfer) zoneFileFor(target string) string {
+	b := strings.Builder{}
+	for _, rr := range zt.records {
+		if !strings.EqualFold(target, zt.Zone) {
+			rr = renameRR(rr, zt.Zone, target)
+		}
+		b.WriteString(rr.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Report writes pre-flight summary of transfer
+func (zt ZoneTransfer) Report(w io.Writer) error {
+	records := 0
+	byType := map[string]int{}
+	for _, set := range zt.RRSets {
+		records += len(set.RRSet.Records)
+		byType[set.RRSet.Type]++
+	}
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "zone: %s\n", zt.Zone)
+	fmt.Fprintf(&b, "serial: %d\n", zt.Serial)
+	fmt.Fprintf(&b, "rrsets: %d\n", len(zt.RRSets))
+	fmt.Fprintf(&b, "records: %d\n", records)
+	for _, t := range types {
+		fmt.Fprintf(&b, "  %s: %d\n", t, byType[t])
+	}
+	fmt.Fprintf(&b, "skipped: %d\n", len(zt.Skipped))
+	for _, s := range zt.Skipped {
+		fmt.Fprintf(&b, "  %s ; %s\n", s.Record, s.Reason)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// MigrateMode how transferred records are put into G-Core zone
+type MigrateMode int
+
+// Migration modes of MigrateZone
+const (
+	// MigrateImport sends transferred zone file to ImportZone
+	MigrateImport MigrateMode = iota
+	// MigrateReconcile creates or updates every transferred RRSet one by one
+	MigrateReconcile
+)
+
+// MigrateOptions for MigrateZone
+type MigrateOptions struct {
+	Mode MigrateMode
+	// Target zone name in G-Core, source zone name when empty
+	Target string
+	// Report receives pre-flight report, optional
+	Report io.Writer
+	// DryRun stops after pre-flight report
+	DryRun bool
+}
+
+// MigrationResult of MigrateZone
+type MigrationResult struct {
+	Transfer  ZoneTransfer
+	Import    *ImportZoneResponse
+	Created   []string
+	Updated   []string
+	Unchanged []string
+}
+
+// MigrateZone does AXFR from src and puts transferred records into G-Core zone.
+// Target zone should exist.
+func (c *Client) MigrateZone(ctx context.Context, src AXFRSource, opts MigrateOptions) (MigrationResult, error) {
+	transfer, err := TransferZone(ctx, src)
+	if err != nil {
+		return MigrationResult{}, fmt.Errorf("transfer: %w", err)
+	}
+	res := MigrationResult{Transfer: transfer}
+	if opts.Report != nil {
+		if err = transfer.Report(opts.Report); err != nil {
+			return res, fmt.Errorf("report: %w", err)
+		}
+	}
+	if opts.DryRun {
+		return res, nil
+	}
+	target := strings.Trim(opts.Target, ".")
+	if target == "" {
+		target = transfer.Zone
+	}
+
+	switch opts.Mode {
+	case MigrateImport:
+		imported, errImport := c.ImportZone(ctx, target, transfer.zoneFileFor(target))
+		if errImport != nil {
+			return res, errImport
+		}
+		res.Import = &imported
+	case MigrateReconcile:
+		for _, set := range transfer.RRSets {
+			name := renameHost(set.Name, transfer.Zone, target)
+			desired := set.RRSet
+			desired.Records = renameRecords(desired.Type, desired.Records, transfer.Zone, target)
+			status, errSet := c.reconcileRRSet(ctx, target, name, desired)
+			if errSet != nil {
+				return res, fmt.Errorf("reconcile %s %s: %w", name, set.RRSet.Type, errSet)
+			}
+			switch status {
+			case http.MethodPost:
+				res.Created = append(res.Created, name+" "+set.RRSet.Type)
+			case http.MethodPut:
+				res.Updated = append(res.Updated, name+" "+set.RRSet.Type)
+			default:
+				res.Unchanged = append(res.Unchanged, name+" "+set.RRSet.Type)
+			}
+		}
+	default:
+		// nolint: goerr113
+		return res, fmt.Errorf("unknown migrate mode %d", opts.Mode)
+	}
+
+	return res, nil
+}
+
+// reconcileRRSet returns http method used to apply RRSet, empty when nothing changed
+func (c *Client) reconcileRRSet(ctx context.Context, zone, name string, desired RRSet) (string, error) {
+	current, err := c.RRSet(ctx, zone, name, desired.Type, 0, 0)
+	if err != nil {
+		errAPI := new(APIError)
+		if !errors.As(err, errAPI) || errAPI.StatusCode != http.StatusNotFound {
+			return "", err
+		}
+		return http.MethodPost, c.CreateRRSet(ctx, zone, name, desired.Type, desired)
+	}
+	if current.TTL == desired.TTL && sameContents(current.Records, desired.Records) {
+		return "", nil
+	}
+	desired.Filters, desired.Meta = current.Filters, current.Meta
+	return http.MethodPut, c.UpdateRRSet(ctx, zone, name, desired.Type, desired)
+}
+
+func sameContents(a, b []ResourceRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, r := range a {
+		counts[r.ContentToString()]++
+	}
+	for _, r := range b {
+		counts[r.ContentToString()]--
+	}
+	for _, v := range counts {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// renameRR moves owner and in-zone host names of record data from zone to target zone
+func renameRR(rr dns.RR, zone, target string) dns.RR {
+	rr = dns.Copy(rr)
+	rr.Header().Name = renameHost(rr.Header().Name, zone, target)
+	switch v := rr.(type) {
+	case *dns.CNAME:
+		v.Target = renameHost(v.Target, zone, target)
+	case *dns.DNAME:
+		v.Target = renameHost(v.Target, zone, target)
+	case *dns.NS:
+		v.Ns = renameHost(v.Ns, zone, target)
+	case *dns.PTR:
+		v.Ptr = renameHost(v.Ptr, zone, target)
+	case *dns.MX:
+		v.Mx = renameHost(v.Mx, zone, target)
+	case *dns.SRV:
+		v.Target = renameHost(v.Target, zone, target)
+	case *dns.SVCB:
+		v.Target = renameHost(v.Target, zone, target)
+	case *dns.HTTPS:
+		v.Target = renameHost(v.Target, zone, target)
+	}
+	return rr
+}
@@ -0,0 +1,277 @@
+package dnssdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAXFRZone = `
+$ORIGIN example.com.
+@       3600 IN SOA   ns1.example.com. admin.example.com. 2024010101 7200 3600 1209600 300
+@       3600 IN NS    ns1.example.com.
+@       300  IN A     192.0.2.1
+@       300  IN MX    10 mail.example.com.
+@       300  IN TXT   "v=spf1 " "-all"
+@       300  IN CAA   0 issue "letsencrypt.org"
+www     300  IN A     192.0.2.2
+www     60   IN A     192.0.2.3
+alias   300  IN CNAME www.example.com.
+_sip._tcp 300 IN SRV  10 20 5060 sip.example.com.
+@       300  IN RRSIG A 13 2 300 20240201000000 20240101000000 12345 example.com. dGVzdA==
+`
+
+func setupAXFRServer(t *testing.T, tsig map[string]string, provider ...dns.TsigProvider) string {
+	t.Helper()
+
+	var records []dns.RR
+	zp := dns.NewZoneParser(bytes.NewBufferString(testAXFRZone), "", "")
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		records = append(records, rr)
+	}
+	require.NoError(t, zp.Err())
+	// AXFR ends by SOA
+	records = append(records, records[0])
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &dns.Server{
+		Listener:   listener,
+		TsigSecret: tsig,
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			if req.Question[0].Qtype != dns.TypeAXFR || req.Question[0].Name != "example.com." ||
+				(tsig != nil && (req.IsTsig() == nil || w.TsigStatus() != nil)) {
+				m := new(dns.Msg)
+				m.SetRcode(req, dns.RcodeRefused)
+				_ = w.WriteMsg(m)
+				return
+			}
+			ch := make(chan *dns.Envelope)
+			tr := new(dns.Transfer)
+			go func() {
+				ch <- &dns.Envelope{RR: records[:4]}
+				ch <- &dns.Envelope{RR: records[4:]}
+				close(ch)
+			}()
+			_ = tr.Out(w, req, ch)
+		}),
+	}
+	if len(provider) > 0 {
+		server.TsigProvider = provider[0]
+	}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	return listener.Addr().String()
+}
+
+func TestTransferZone(t *testing.T) {
+	addr := setupAXFRServer(t, nil)
+
+	transfer, err := TransferZone(context.Background(), AXFRSource{Server: addr, Zone: "example.com."})
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", transfer.Zone)
+	assert.Equal(t, uint32(2024010101), transfer.Serial)
+	assert.Equal(t, []TransferredRRSet{
+		{Name: "_sip._tcp.example.com", RRSet: RRSet{Type: "SRV", TTL: 300, Records: []ResourceRecord{
+			{Content: []any{int64(10), int64(20), int64(5060), "sip.example.com."}, Enabled: true},
+		}}},
+		{Name: "alias.example.com", RRSet: RRSet{Type: "CNAME", TTL: 300, Records: []ResourceRecord{
+			{Content: []any{"www.example.com."}, Enabled: true},
+		}}},
+		{Name: "example.com", RRSet: RRSet{Type: "A", TTL: 300, Records: []ResourceRecord{
+			{Content: []any{"192.0.2.1"}, Enabled: true},
+		}}},
+		{Name: "example.com", RRSet: RRSet{Type: "CAA", TTL: 300, Records: []ResourceRecord{
+			{Content: []any{int64(0), "issue", "letsencrypt.org"}, Enabled: true},
+		}}},
+		{Name: "example.com", RRSet: RRSet{Type: "MX", TTL: 300, Records: []ResourceRecord{
+			{Content: []any{int64(10), "mail.example.com."}, Enabled: true},
+		}}},
+		{Name: "example.com", RRSet: RRSet{Type: "TXT", TTL: 300, Records: []ResourceRecord{
+			{Content: []any{"v=spf1 -all"}, Enabled: true},
+		}}},
+		{Name: "www.example.com", RRSet: RRSet{Type: "A", TTL: 60, Records: []ResourceRecord{
+			{Content: []any{"192.0.2.2"}, Enabled: true},
+			{Content: []any{"192.0.2.3"}, Enabled: true},
+		}}},
+	}, transfer.RRSets)
+	require.Len(t, transfer.Skipped, 2)
+	assert.Equal(t, "apex ns is managed by G-Core", transfer.Skipped[0].Reason)
+	assert.Equal(t, "dnssec is managed by ToggleDnssec", transfer.Skipped[1].Reason)
+
+	report := bytes.Buffer{}
+	require.NoError(t, transfer.Report(&report))
+	assert.Contains(t, report.String(), "rrsets: 7\nrecords: 8\n")
+	assert.Contains(t, report.String(), "skipped: 2\n")
+}
+
+func TestTransferZone_TSIG(t *testing.T) {
+	key := TSIGKey{Name: "transfer-key", Algorithm: TSIGAlgorithmHMACSHA256, Secret: "c2VjcmV0c2VjcmV0c2VjcmV0"}
+	addr := setupAXFRServer(t, map[string]string{"transfer-key.": key.Secret})
+
+	_, err := TransferZone(context.Background(), AXFRSource{Server: addr, Zone: "example.com", Timeout: time.Second})
+	require.Error(t, err)
+
+	transfer, err := TransferZone(context.Background(), AXFRSource{Server: addr, Zone: "example.com", TSIG: &key})
+	require.NoError(t, err)
+	assert.Len(t, transfer.RRSets, 7)
+}
+
+func TestTransferZone_TSIGMD5(t *testing.T) {
+	key := TSIGKey{Name: "Transfer-Key", Algorithm: "HMAC-MD5", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0"}
+	secret, _ := base64.StdEncoding.DecodeString(key.Secret)
+	var algorithm string
+	provider := tsigHMAC{name: "transfer-key.", secret: secret}
+	addr := setupAXFRServer(t, map[string]string{"transfer-key.": key.Secret}, tsigRecorder{tsigHMAC: provider, algorithm: &algorithm})
+
+	transfer, err := TransferZone(context.Background(), AXFRSource{Server: addr, Zone: "example.com", TSIG: &key})
+	require.NoError(t, err)
+	assert.Len(t, transfer.RRSets, 7)
+	assert.Equal(t, "hmac-md5.sig-alg.reg.int.", algorithm)
+}
+
+// tsigRecorder remembers algorithm of verified request
+type tsigRecorder struct {
+	tsigHMAC
+	algorithm *string
+}
+
+func (r tsigRecorder) Verify(msg []byte, t *dns.TSIG) error {
+	*r.algorithm = t.Algorithm
+	return r.tsigHMAC.Verify(msg, t)
+}
+
+func TestRRValue_CAA(t *testing.T) {
+	rr, err := dns.NewRR(`example.com. 300 IN CAA 0 issue "ca.example.net; account=230123"`)
+	require.NoError(t, err)
+	value := rrValue(rr)
+	assert.Equal(t, `0 issue "ca.example.net; account=230123"`, value)
+	assert.Equal(t, []any{int64(0), "issue", "ca.example.net; account=230123"}, ContentFromValue("CAA", value))
+
+	parsed, err := dns.NewRR("example.com. 300 IN CAA " + value)
+	require.NoError(t, err)
+	assert.Equal(t, rr.String(), parsed.String())
+}
+
+func TestRenameHost(t *testing.T) {
+	for host, want := range map[string]string{
+		"example.com":           "example.org",
+		"WWW.Example.COM.":      "WWW.example.org.",
+		"mail.other.com.":       "mail.other.com.",
+		"notexample.com":        "notexample.com",
+		"example.com.evil.":     "example.com.evil.",
+		"a.b.example.com":       "a.b.example.org",
+		"_sip._tcp.EXAMPLE.com": "_sip._tcp.example.org",
+	} {
+		assert.Equal(t, want, renameHost(host, "example.com.", "example.org"), host)
+	}
+	assert.Equal(t, "WWW.Example.COM", renameHost("WWW.Example.COM", "example.com", "EXAMPLE.com"))
+}
+
+func TestClient_MigrateZone_Import(t *testing.T) {
+	addr := setupAXFRServer(t, nil)
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/zones/example.org/import", validationHandler{
+		method: http.MethodPost,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			body := ImportZone{}
+			err := json.NewDecoder(req.Body).Decode(&body)
+			if err != nil {
+				http.Error(rw, "failed to decode body", http.StatusBadRequest)
+				return
+			}
+			assert.Contains(t, body.Content, "www.example.org.\t300\tIN\tA\t192.0.2.2\n")
+			assert.Contains(t, body.Content, "alias.example.org.\t300\tIN\tCNAME\twww.example.org.\n")
+			assert.Contains(t, body.Content, "example.org.\t300\tIN\tMX\t10 mail.example.org.\n")
+			assert.NotContains(t, body.Content, "RRSIG")
+			handleJSONResponse(ImportZoneResponse{Success: true})(rw, req)
+		}),
+	})
+
+	res, err := client.MigrateZone(context.Background(),
+		AXFRSource{Server: addr, Zone: "example.com"}, MigrateOptions{Mode: MigrateImport, Target: "example.org"})
+	require.NoError(t, err)
+	require.NotNil(t, res.Import)
+	assert.True(t, res.Import.Success)
+}
+
+func TestClient_MigrateZone_Reconcile(t *testing.T) {
+	addr := setupAXFRServer(t, nil)
+	mux, client := setupTest(t)
+
+	mux.HandleFunc("/v2/zones/", func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/v2/zones/example.com/www.example.com/A" && req.Method == http.MethodGet:
+			handleJSONResponse(RRSet{Type: "A", TTL: 60, Records: []ResourceRecord{
+				{Content: []any{"192.0.2.3"}}, {Content: []any{"192.0.2.2"}},
+			}})(rw, req)
+		case req.URL.Path == "/v2/zones/example.com/example.com/A" && req.Method == http.MethodGet:
+			handleJSONResponse(RRSet{Type: "A", TTL: 60, Records: []ResourceRecord{{Content: []any{"192.0.2.9"}}}})(rw, req)
+		case req.Method == http.MethodGet:
+			rw.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(rw).Encode(APIError{Message: "not found"})
+		}
+	})
+
+	report := bytes.Buffer{}
+	res, err := client.MigrateZone(context.Background(),
+		AXFRSource{Server: addr, Zone: "example.com"}, MigrateOptions{Mode: MigrateReconcile, Report: &report})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com A"}, res.Updated)
+	assert.Equal(t, []string{"www.example.com A"}, res.Unchanged)
+	assert.Len(t, res.Created, 5)
+	assert.Contains(t, report.String(), "zone: example.com\n")
+}
+
+func TestClient_MigrateZone_ReconcileTarget(t *testing.T) {
+	addr := setupAXFRServer(t, nil)
+	mux, client := setupTest(t)
+
+	created := map[string]RRSet{}
+	mux.HandleFunc("/v2/zones/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet {
+			rw.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(rw).Encode(APIError{Message: "not found"})
+			return
+		}
+		var set RRSet
+		_ = json.NewDecoder(req.Body).Decode(&set)
+		created[req.URL.Path] = set
+	})
+
+	_, err := client.MigrateZone(context.Background(),
+		AXFRSource{Server: addr, Zone: "example.com"}, MigrateOptions{Mode: MigrateReconcile, Target: "example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"www.example.org."}, created["/v2/zones/example.org/alias.example.org/CNAME"].Records[0].Content)
+	assert.Equal(t, []any{float64(10), "mail.example.org."},
+		created["/v2/zones/example.org/example.org/MX"].Records[0].Content)
+	assert.Equal(t, []any{float64(10), float64(20), float64(5060), "sip.example.org."},
+		created["/v2/zones/example.org/_sip._tcp.example.org/SRV"].Records[0].Content)
+}
+
+func TestClient_MigrateZone_DryRun(t *testing.T) {
+	addr := setupAXFRServer(t, nil)
+	_, client := setupTest(t)
+
+	report := bytes.Buffer{}
+	res, err := client.MigrateZone(context.Background(),
+		AXFRSource{Server: addr, Zone: "example.com"}, MigrateOptions{DryRun: true, Report: &report})
+	require.NoError(t, err)
+	assert.Nil(t, res.Import)
+	assert.Contains(t, report.String(), "serial: 2024010101\n")
+}
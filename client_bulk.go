@@ -0,0 +1,158 @@
+package dnssdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const defaultBulkWorkers = 10
+
+// BulkOptions for bulk operations
+type BulkOptions struct {
+	// Workers amount of parallel requests, 10 when empty
+	Workers int
+	// Progress is called after every processed item, calls are not concurrent
+	Progress func(done, total int, item BulkItemResult)
+}
+
+// BulkItemResult result of one item of bulk operation
+type BulkItemResult struct {
+	// Item zone name or "zone name type" for RRSets
+	Item string
+	// ID of created zone
+	ID  uint64
+	Err error
+}
+
+// BulkResult report of bulk operation in order of input items
+type BulkResult struct {
+	Items []BulkItemResult
+}
+
+// Failed items of bulk operation
+func (r BulkResult) Failed() []BulkItemResult {
+	var res []BulkItemResult
+	for _, item := range r.Items {
+		if item.Err != nil {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// Err describes all failed items, nil when every item succeeded
+func (r BulkResult) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(failed))
+	for i, item := range failed {
+		msgs[i] = fmt.Sprintf("%s: %s", item.Item, item.Err)
+	}
+	// nolint: goerr113
+	return fmt.Errorf("%d of %d failed: %s", len(failed), len(r.Items), strings.Join(msgs, "; "))
+}
+
+// BulkRRSet RRSet to upsert into zone
+type BulkRRSet struct {
+	Zone  string
+	Name  string
+	RRSet RRSet
+}
+
+// BulkCreateZones creates zones, continues on error.
+func (c *Client) BulkCreateZones(ctx context.Context, zones []AddZone, opts BulkOptions) BulkResult {
+	return runBulk(ctx, len(zones), opts, func(ctx context.Context, i int) BulkItemResult {
+		id, err := c.CreateZone(ctx, zones[i])
+		return BulkItemResult{Item: zones[i].Name, ID: id, Err: err}
+	})
+}
+
+// BulkDeleteZones deletes zones, continues on error.
+func (c *Client) BulkDeleteZones(ctx context.Context, names []string, opts BulkOptions) BulkResult {
+	return runBulk(ctx, len(names), opts, func(ctx context.Context, i int) BulkItemResult {
+		return BulkItemResult{Item: names[i], Err: c.DeleteZone(ctx, names[i])}
+	})
+}
+
+// BulkEnableZones enables zones, continues on error.
+func (c *Client) BulkEnableZones(ctx context.Context, names []string, opts BulkOptions) BulkResult {
+	return runBulk(ctx, len(names), opts, func(ctx context.Context, i int) BulkItemResult {
+		return BulkItemResult{Item: names[i], Err: c.EnableZone(ctx, names[i])}
+	})
+}
+
+// BulkDisableZones disables zones, continues on error.
+func (c *Client) BulkDisableZones(ctx context.Context, names []string, opts BulkOptions) BulkResult {
+	return runBulk(ctx, len(names), opts, func(ctx context.Context, i int) BulkItemResult {
+		return BulkItemResult{Item: names[i], Err: c.DisableZone(ctx, names[i])}
+	})
+}
+
+// BulkUpsertRRSets creates or replaces RRSets across zones, continues on error.
+func (c *Client) BulkUpsertRRSets(ctx context.Context, sets []BulkRRSet, opts BulkOptions) BulkResult {
+	return runBulk(ctx, len(sets), opts, func(ctx context.Context, i int) BulkItemResult {
+		s := sets[i]
+		return BulkItemResult{
+			Item: fmt.Sprintf("%s %s %s", s.Zone, s.Name, s.RRSet.Type),
+			Err:  c.upsertRRSet(ctx, s.Zone, s.Name, s.RRSet.Type, s.RRSet),
+		}
+	})
+}
+
+// upsertRRSet replaces RRSet or creates it when it does not exist
+func (c *Client) upsertRRSet(ctx context.Context, zone, name, recordType string, record RRSet) error {
+	_, err := c.RRSet(ctx, zone, name, recordType, 0, 0)
+	if err == nil {
+		return c.UpdateRRSet(ctx, zone, name, recordType, record)
+	}
+	if isNotFound(err) {
+		return c.CreateRRSet(ctx, zone, name, recordType, record)
+	}
+	return fmt.Errorf("rrset: %w", err)
+}
+
+// runBulk calls fn for every of n items by pool of workers
+func runBulk(ctx context.Context, n int, opts BulkOptions, fn func(ctx context.Context, i int) BulkItemResult) BulkResult {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultBulkWorkers
+	}
+	if workers > n {
+		workers = n
+	}
+
+	res := BulkResult{Items: make([]BulkItemResult, n)}
+	jobs := make(chan int)
+	mu := sync.Mutex{}
+	done := 0
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				item := fn(ctx, i)
+				mu.Lock()
+				res.Items[i] = item
+				done++
+				if opts.Progress != nil {
+					opts.Progress(done, n, item)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// canceled context fails the rest of items fast, so they are still reported
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return res
+}
@@ -0,0 +1,117 @@
+package dnssdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_BulkCreateZones(t *testing.T) {
+	mux, client := setupTest(t)
+
+	var ids uint64
+	mux.Handle("/v2/zones", validationHandler{
+		method: http.MethodPost,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			body := AddZone{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			if body.Name == "bad.com" {
+				handleAPIError()(rw, req)
+				return
+			}
+			handleJSONResponse(CreateResponse{ID: atomic.AddUint64(&ids, 1)})(rw, req)
+		}),
+	})
+
+	var calls []int
+	res := client.BulkCreateZones(context.Background(),
+		[]AddZone{{Name: "a.com"}, {Name: "bad.com"}, {Name: "c.com"}},
+		BulkOptions{Workers: 2, Progress: func(done, total int, item BulkItemResult) {
+			assert.Equal(t, 3, total)
+			calls = append(calls, done)
+		}})
+
+	require.Len(t, res.Items, 3)
+	assert.Equal(t, []int{1, 2, 3}, calls)
+	assert.Equal(t, "a.com", res.Items[0].Item)
+	assert.NotZero(t, res.Items[0].ID)
+	assert.NotZero(t, res.Items[2].ID)
+	require.Len(t, res.Failed(), 1)
+	assert.Equal(t, "bad.com", res.Failed()[0].Item)
+	assert.EqualError(t, res.Err(), "1 of 3 failed: bad.com: request: 500: oops")
+}
+
+func TestClient_BulkDeleteZones(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/zones/a.com", validationHandler{method: http.MethodDelete})
+	mux.Handle("/v2/zones/b.com", validationHandler{method: http.MethodDelete})
+
+	res := client.BulkDeleteZones(context.Background(), []string{"a.com", "b.com"}, BulkOptions{})
+	require.NoError(t, res.Err())
+	assert.Len(t, res.Items, 2)
+}
+
+func TestClient_BulkEnableDisableZones(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/zones/a.com/enable", validationHandler{method: http.MethodPatch})
+	mux.Handle("/v2/zones/a.com/disable", validationHandler{method: http.MethodPatch})
+
+	res := client.BulkEnableZones(context.Background(), []string{"a.com", "missing.com"}, BulkOptions{})
+	assert.Len(t, res.Failed(), 1)
+	assert.Equal(t, "missing.com", res.Failed()[0].Item)
+
+	res = client.BulkDisableZones(context.Background(), []string{"a.com"}, BulkOptions{})
+	require.NoError(t, res.Err())
+}
+
+func TestClient_BulkUpsertRRSets(t *testing.T) {
+	mux, client := setupTest(t)
+
+	var created, updated int32
+	mux.HandleFunc("/v2/zones/a.com/www.a.com/A", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			handleJSONResponse(RRSet{Type: "A"})(rw, req)
+		case http.MethodPut:
+			atomic.AddInt32(&updated, 1)
+		}
+	})
+	mux.HandleFunc("/v2/zones/b.com/www.b.com/A", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			rw.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(rw).Encode(APIError{Message: "not found"})
+		case http.MethodPost:
+			atomic.AddInt32(&created, 1)
+		}
+	})
+
+	set := RRSet{Type: "A", TTL: 300, Records: []ResourceRecord{{Content: []any{"192.0.2.1"}}}}
+	res := client.BulkUpsertRRSets(context.Background(), []BulkRRSet{
+		{Zone: "a.com", Name: "www.a.com", RRSet: set},
+		{Zone: "b.com", Name: "www.b.com", RRSet: set},
+	}, BulkOptions{Workers: 1})
+
+	require.NoError(t, res.Err())
+	assert.Equal(t, "a.com www.a.com A", res.Items[0].Item)
+	assert.Equal(t, int32(1), created)
+	assert.Equal(t, int32(1), updated)
+}
+
+func TestClient_BulkCanceled(t *testing.T) {
+	_, client := setupTest(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := client.BulkDeleteZones(ctx, []string{"a.com", "b.com", "c.com"}, BulkOptions{Workers: 1})
+	require.Len(t, res.Failed(), 3)
+	assert.ErrorIs(t, res.Items[2].Err, context.Canceled)
+}
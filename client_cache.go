@@ -0,0 +1,197 @@
+package dnssdk
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStats metrics of response cache
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Evictions     uint64
+	Invalidations uint64
+	Entries       int
+}
+
+// WithCache enables read-through cache of GET responses.
+// Every write attempt through the same Client, failed ones too, invalidates cached responses
+// of the affected zone or collection, state on the server is unknown after a failed write.
+func WithCache(ttl time.Duration, maxEntries int) func(*Client) {
+	return func(client *Client) {
+		client.cache = newResponseCache(ttl, maxEntries)
+	}
+}
+
+// CacheStats returns metrics of response cache, zero when cache is disabled
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.stats()
+}
+
+// PurgeCache removes all cached responses
+func (c *Client) PurgeCache() {
+	if c.cache == nil {
+		return
+	}
+	c.cache.purge()
+}
+
+// collections where the same item is addressed by id and by name, so the whole collection is invalidated
+var cacheAliasedCollections = map[string]bool{
+	"/v2/network-mappings": true,
+}
+
+type cacheEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+// responseCache is LRU of response bodies keyed by uri of GET request
+type responseCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	st         CacheStats
+	now        func() time.Time
+	// generations of collections and their items bumped by invalidate,
+	// response read before concurrent write is not cached after its invalidate
+	collectionGens map[string]uint64
+	itemGens       map[string]uint64
+}
+
+func newResponseCache(ttl time.Duration, maxEntries int) *responseCache {
+	return &responseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      map[string]*list.Element{},
+		now:        time.Now,
+
+		collectionGens: map[string]uint64{},
+		itemGens:       map[string]uint64{},
+	}
+}
+
+// get cached body and generation of uri to pass to set after request
+func (rc *responseCache) get(uri string) ([]byte, uint64, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	gen := rc.generation(uri)
+	el, ok := rc.items[uri]
+	if !ok {
+		rc.st.Misses++
+		return nil, gen, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if rc.now().After(entry.expires) {
+		rc.remove(el)
+		rc.st.Misses++
+		return nil, gen, false
+	}
+	rc.ll.MoveToFront(el)
+	rc.st.Hits++
+	return entry.body, gen, true
+}
+
+// set body of uri unless it was invalidated after get returned gen
+func (rc *responseCache) set(uri string, body []byte, gen uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.generation(uri) != gen {
+		return
+	}
+	if el, ok := rc.items[uri]; ok {
+		el.Value.(*cacheEntry).body = body
+		el.Value.(*cacheEntry).expires = rc.now().Add(rc.ttl)
+		rc.ll.MoveToFront(el)
+		return
+	}
+	rc.items[uri] = rc.ll.PushFront(&cacheEntry{key: uri, body: body, expires: rc.now().Add(rc.ttl)})
+	for rc.maxEntries > 0 && rc.ll.Len() > rc.maxEntries {
+		rc.remove(rc.ll.Back())
+		rc.st.Evictions++
+	}
+}
+
+// invalidate drops cached responses affected by write request to uri:
+// the written item with all its sub-resources and the listings of its collection
+func (rc *responseCache) invalidate(uri string) {
+	collection, item := splitCacheURI(uri)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if item == "" || cacheAliasedCollections[collection] {
+		rc.collectionGens[collection]++
+	} else {
+		rc.itemGens[collection+"/"+item]++
+		// listings of collection
+		rc.itemGens[collection+"/"]++
+	}
+	for key, el := range rc.items {
+		keyCollection, keyItem := splitCacheURI(key)
+		if keyCollection != collection {
+			continue
+		}
+		if item == "" || keyItem == "" || keyItem == item || cacheAliasedCollections[collection] {
+			rc.remove(el)
+			rc.st.Invalidations++
+		}
+	}
+}
+
+// generation of uri changes on every invalidate which drops it
+func (rc *responseCache) generation(uri string) uint64 {
+	collection, item := splitCacheURI(uri)
+	return rc.collectionGens[collection] + rc.itemGens[collection+"/"+item]
+}
+
+func (rc *responseCache) purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.ll.Init()
+	rc.items = map[string]*list.Element{}
+}
+
+func (rc *responseCache) stats() CacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	st := rc.st
+	st.Entries = rc.ll.Len()
+	return st
+}
+
+func (rc *responseCache) remove(el *list.Element) {
+	rc.ll.Remove(el)
+	delete(rc.items, el.Value.(*cacheEntry).key)
+}
+
+// splitCacheURI /v2/zones/example.com/www.example.com/A?limit=1 -> /v2/zones, example.com
+func splitCacheURI(uri string) (collection, item string) {
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		uri = uri[:i]
+	}
+	parts := strings.SplitN(strings.Trim(uri, "/"), "/", 4)
+	// nolint: gomnd
+	if len(parts) < 2 {
+		return "/" + strings.Join(parts, "/"), ""
+	}
+	collection = "/" + parts[0] + "/" + parts[1]
+	// nolint: gomnd
+	if len(parts) > 2 {
+		item = strings.Trim(parts[2], ".")
+	}
+	return collection, item
+}
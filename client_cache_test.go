@@ -0,0 +1,178 @@
+package dnssdk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countingHandler(counter *int32, next http.Handler) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(counter, 1)
+		next.ServeHTTP(rw, req)
+	}
+}
+
+func TestClient_WithCache(t *testing.T) {
+	mux, client := setupTest(t)
+	WithCache(time.Minute, 10)(client)
+
+	var zoneCalls, rrsetCalls, otherZoneCalls int32
+	mux.Handle("/v2/zones/example.com", countingHandler(&zoneCalls, validationHandler{
+		method: http.MethodGet,
+		next:   handleJSONResponse(Zone{Name: "example.com"}),
+	}))
+	mux.Handle("/v2/zones/example.org", countingHandler(&otherZoneCalls, validationHandler{
+		method: http.MethodGet,
+		next:   handleJSONResponse(Zone{Name: "example.org"}),
+	}))
+	mux.HandleFunc("/v2/zones/example.com/www.example.com/A", countingHandler(&rrsetCalls,
+		http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodGet {
+				handleJSONResponse(RRSet{Type: "A", TTL: 300})(rw, req)
+			}
+		})))
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		zone, err := client.Zone(ctx, "example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com", zone.Name)
+		_, err = client.RRSet(ctx, "example.com", "www.example.com", "A", 0, 0)
+		require.NoError(t, err)
+		_, err = client.Zone(ctx, "example.org")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), zoneCalls)
+	assert.Equal(t, int32(1), rrsetCalls)
+	assert.Equal(t, CacheStats{Hits: 6, Misses: 3, Entries: 3}, client.CacheStats())
+
+	// write invalidates the zone but not another one
+	err := client.UpdateRRSet(ctx, "example.com", "www.example.com", "A", RRSet{TTL: 60})
+	require.NoError(t, err)
+
+	_, err = client.Zone(ctx, "example.com")
+	require.NoError(t, err)
+	_, err = client.RRSet(ctx, "example.com", "www.example.com", "A", 0, 0)
+	require.NoError(t, err)
+	_, err = client.Zone(ctx, "example.org")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), zoneCalls)
+	assert.Equal(t, int32(3), rrsetCalls) // get, put, get
+	assert.Equal(t, int32(1), otherZoneCalls)
+	assert.Equal(t, uint64(2), client.CacheStats().Invalidations)
+
+	client.PurgeCache()
+	assert.Equal(t, 0, client.CacheStats().Entries)
+}
+
+func TestClient_WithCache_errorsNotCached(t *testing.T) {
+	mux, client := setupTest(t)
+	WithCache(time.Minute, 10)(client)
+
+	var calls int32
+	mux.Handle("/v2/zones/example.com", countingHandler(&calls, validationHandler{
+		method: http.MethodGet,
+		next:   handleAPIError(),
+	}))
+
+	_, err := client.Zone(context.Background(), "example.com")
+	require.Error(t, err)
+	_, err = client.Zone(context.Background(), "example.com")
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestResponseCache_ttlAndSize(t *testing.T) {
+	now := time.Now()
+	rc := newResponseCache(time.Minute, 2)
+	rc.now = func() time.Time { return now }
+
+	rc.set("/v2/zones/a.com", []byte("a"), 0)
+	rc.set("/v2/zones/b.com", []byte("b"), 0)
+	_, _, ok := rc.get("/v2/zones/a.com")
+	require.True(t, ok)
+	rc.set("/v2/zones/c.com", []byte("c"), 0)
+
+	_, _, ok = rc.get("/v2/zones/b.com")
+	assert.False(t, ok, "least recently used is evicted")
+	_, _, ok = rc.get("/v2/zones/a.com")
+	assert.True(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	_, _, ok = rc.get("/v2/zones/a.com")
+	assert.False(t, ok, "expired")
+
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 2, Evictions: 1, Entries: 1}, rc.stats())
+}
+
+func TestResponseCache_invalidate(t *testing.T) {
+	rc := newResponseCache(time.Minute, 0)
+	for _, uri := range []string{
+		"/v2/zones?limit=100&",
+		"/v2/zones/a.com",
+		"/v2/zones/a.com/rrsets?all=true&type=NS",
+		"/v2/zones/b.com",
+		"/v2/network-mappings/1",
+		"/v2/network-mappings/name",
+	} {
+		rc.set(uri, nil, 0)
+	}
+
+	rc.invalidate("/v2/zones/a.com./dnssec")
+	_, _, ok := rc.get("/v2/zones/a.com/rrsets?all=true&type=NS")
+	assert.False(t, ok)
+	_, _, ok = rc.get("/v2/zones?limit=100&")
+	assert.False(t, ok)
+	_, _, ok = rc.get("/v2/zones/b.com")
+	assert.True(t, ok)
+
+	rc.invalidate("/v2/network-mappings/1")
+	_, _, ok = rc.get("/v2/network-mappings/name")
+	assert.False(t, ok)
+}
+
+func TestClient_WithCache_concurrentWrite(t *testing.T) {
+	mux, client := setupTest(t)
+	WithCache(time.Minute, 10)(client)
+
+	var calls int32
+	started := make(chan struct{})
+	written := make(chan struct{})
+	mux.HandleFunc("/v2/zones/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			handleJSONResponse(CreateResponse{ID: 1})(rw, req)
+			return
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// first read gets state before concurrent write lands
+			close(started)
+			<-written
+			handleJSONResponse(Zone{Name: "example.com", Serial: 1})(rw, req)
+			return
+		}
+		handleJSONResponse(Zone{Name: "example.com", Serial: 2})(rw, req)
+	})
+
+	ctx := context.Background()
+	done := make(chan Zone)
+	go func() {
+		zone, _ := client.Zone(ctx, "example.com")
+		done <- zone
+	}()
+	<-started
+	_, err := client.UpdateZone(ctx, "example.com", AddZone{Name: "example.com"})
+	require.NoError(t, err)
+	close(written)
+	assert.Equal(t, uint64(1), (<-done).Serial)
+
+	zone, err := client.Zone(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), zone.Serial, "stale read is not cached")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
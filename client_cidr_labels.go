@@ -0,0 +1,224 @@
+package dnssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const metaCidrLabels = "cidr_labels"
+
+// ErrCidrLabels cidr_labels of records do not match tags of linked network mapping
+var ErrCidrLabels = errors.New("cidr_labels do not match network mapping")
+
+// CidrLabelsReport of ValidateCidrLabels
+type CidrLabelsReport struct {
+	Mapping  string
+	Findings []Finding
+	// UnusedTags of mapping which are not cidr_labels of any record
+	UnusedTags []string
+	// Unreachable indexes of records which are never selected by mapping
+	Unreachable []int
+}
+
+// Err is ErrCidrLabels with messages of error findings, nil without them
+func (r CidrLabelsReport) Err() error {
+	var msgs []string
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			msgs = append(msgs, f.Message)
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w %s: %s", ErrCidrLabels, r.Mapping, strings.Join(msgs, "; "))
+}
+
+// LinkNetworkMappingOptions of LinkNetworkMapping
+type LinkNetworkMappingOptions struct {
+	// Force links mapping despite error findings
+	Force bool
+	// DryRun validates cidr_labels without updating rrset
+	DryRun bool
+}
+
+// LinkNetworkMapping sets geodns_link of rrset to network mapping
+// after ValidateCidrLabels of its records found no errors.
+func (c *Client) LinkNetworkMapping(ctx context.Context, zone, name, recordType, mapping string,
+	opts LinkNetworkMappingOptions) (CidrLabelsReport, error) {
+	current, err := c.GetNetworkMappingByName(ctx, mapping)
+	if err != nil {
+		return CidrLabelsReport{}, fmt.Errorf("get network mapping %s: %w", mapping, err)
+	}
+	rrset, err := c.RRSet(ctx, zone, name, recordType, 0, 0)
+	if err != nil {
+		return CidrLabelsReport{}, fmt.Errorf("get rrset %s %s: %w", name, recordType, err)
+	}
+	if rrset.Type == "" {
+		rrset.Type = recordType
+	}
+
+	report := ValidateCidrLabels(name, rrset, NetworkMappingRequest{Name: current.Name, Mapping: current.Mapping})
+	if err = report.Err(); err != nil && !opts.Force {
+		return report, err
+	}
+	if link, _ := rrset.Meta[metaGeodnsLink].(string); opts.DryRun || link == current.Name {
+		return report, nil
+	}
+	rrset.SetMetaGeodnsLink(current.Name)
+	if err = c.UpdateRRSet(ctx, zone, name, recordType, rrset); err != nil {
+		return report, fmt.Errorf("link rrset %s %s to %s: %w", name, recordType, current.Name, err)
+	}
+	return report, nil
+}
+
+// ValidateCidrLabels checks cidr_labels of enabled records against tags of mapping.
+// Labels which are not tags of mapping are errors, records which are never selected
+// and tags of mapping which are never matched are warnings, tags without records are info.
+func ValidateCidrLabels(name string, rrset RRSet, mapping NetworkMappingRequest) CidrLabelsReport {
+	report := CidrLabelsReport{Mapping: mapping.Name}
+	finding := func(code string, severity Severity, format string, args ...any) {
+		report.Findings = append(report.Findings, Finding{Code: code, Severity: severity,
+			Name: name, Type: rrset.Type, Message: fmt.Sprintf(format, args...)})
+	}
+
+	tags := map[string]bool{}
+	for _, entry := range mapping.Mapping {
+		for _, tag := range entry.Tags {
+			tags[tag] = true
+		}
+	}
+	reachable := NewMappingTree(mapping.Mapping).reachableTags()
+
+	used := map[string]bool{}
+	shadowed := map[string]bool{}
+	for i, record := range rrset.Records {
+		if !record.Enabled {
+			continue
+		}
+		labels, ok, err := recordCidrLabels(record.Meta)
+		if err != nil {
+			finding(LintCidrLabelUnknown, SeverityError, "record %d %s: %v", i, record.ContentToString(), err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		selectable, unknown := false, false
+		for _, label := range labels {
+			used[label] = true
+			switch {
+			case !tags[label]:
+				unknown = true
+				finding(LintCidrLabelUnknown, SeverityError, "record %d %s: cidr_labels %s is not a tag of mapping %s",
+					i, record.ContentToString(), label, mapping.Name)
+			case !reachable[label]:
+				shadowed[label] = true
+			default:
+				selectable = true
+			}
+		}
+		// record with unknown label is already an error
+		if !selectable && !unknown {
+			report.Unreachable = append(report.Unreachable, i)
+			finding(LintRecordUnreachable, SeverityWarning, "record %d %s is never selected, no cidr_labels of it match",
+				i, record.ContentToString())
+		}
+	}
+
+	for _, tag := range sortedKeys(shadowed) {
+		finding(LintCidrTagShadowed, SeverityWarning,
+			"tag %s of mapping %s is never matched, its prefixes are overridden by longer ones", tag, mapping.Name)
+	}
+	for _, tag := range sortedKeys(tags) {
+		if !used[tag] {
+			report.UnusedTags = append(report.UnusedTags, tag)
+			finding(LintCidrTagUnused, SeverityInfo, "tag %s of mapping %s is not used by records", tag, mapping.Name)
+		}
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Severity > report.Findings[j].Severity
+	})
+	return report
+}
+
+// recordCidrLabels sorted labels of cidr_labels meta built by NewResourceMetaCidrLabels or decoded from API
+func recordCidrLabels(meta map[string]any) ([]string, bool, error) {
+	raw, ok := meta[metaCidrLabels]
+	if !ok {
+		return nil, false, nil
+	}
+	var labels []string
+	switch v := raw.(type) {
+	case map[string]int:
+		for label, weight := range v {
+			if weight < 0 {
+				// nolint: goerr113
+				return nil, true, fmt.Errorf("cidr_labels %s has negative value", label)
+			}
+			labels = append(labels, label)
+		}
+	case map[string]any:
+		for label, weight := range v {
+			f, isNum := weight.(float64)
+			if !isNum || f < 0 || f != math.Trunc(f) {
+				// nolint: goerr113
+				return nil, true, fmt.Errorf("cidr_labels %s has invalid value %v", label, weight)
+			}
+			labels = append(labels, label)
+		}
+	default:
+		// nolint: goerr113
+		return nil, true, fmt.Errorf("cidr_labels is %T, not an object", raw)
+	}
+	if len(labels) == 0 {
+		// nolint: goerr113
+		return nil, true, fmt.Errorf("cidr_labels is empty")
+	}
+	sort.Strings(labels)
+	return labels, true, nil
+}
+
+// reachableTags matched by some address, prefixes fully covered by longer ones never match
+func (t *PrefixTree) reachableTags() map[string]bool {
+	res := map[string]bool{}
+	var walk func(n *prefixNode)
+	walk = func(n *prefixNode) {
+		if n == nil {
+			return
+		}
+		if n.prefix != nil && !n.covered() {
+			for _, tag := range n.tags {
+				res[tag] = true
+			}
+		}
+		walk(n.child[0])
+		walk(n.child[1])
+	}
+	walk(t.v4)
+	walk(t.v6)
+	return res
+}
+
+// covered is true when both halves of node are covered by longer prefixes
+func (n *prefixNode) covered() bool {
+	for _, c := range n.child {
+		if c == nil || (c.prefix == nil && !c.covered()) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]bool) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,115 @@
+package dnssdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func labeledRecord(ip string, labels map[string]int) ResourceRecord {
+	r := ResourceRecord{Enabled: true}
+	r.SetContent("A", ip).AddMeta(NewResourceMetaCidrLabels(labels))
+	return r
+}
+
+func TestValidateCidrLabels(t *testing.T) {
+	mapping := NetworkMappingRequest{Name: "office", Mapping: []MappingEntry{
+		{Tags: []string{"eu"}, CIDR4: []IPNet{mustParseCIDR("10.0.0.0/8")}},
+		{Tags: []string{"us"}, CIDR4: []IPNet{mustParseCIDR("10.0.0.0/9")}},
+		{Tags: []string{"asia"}, CIDR4: []IPNet{mustParseCIDR("10.128.0.0/9")}},
+		{Tags: []string{"lab"}, CIDR6: []IPNet{mustParseCIDR("fd00::/8")}},
+		{Tags: []string{"spare"}, CIDR4: []IPNet{mustParseCIDR("192.0.2.0/24")}},
+	}}
+	var decoded ResourceRecord
+	require.NoError(t, json.Unmarshal([]byte(`{"content":["192.0.2.4"],"enabled":true,"meta":{"cidr_labels":{"lab":1}}}`),
+		&decoded))
+	rrset := RRSet{Type: "A", Records: []ResourceRecord{
+		labeledRecord("192.0.2.1", map[string]int{"us": 0, "asia": 1}),
+		labeledRecord("192.0.2.2", map[string]int{"eu": 0}),
+		labeledRecord("192.0.2.3", map[string]int{"apac": 0, "us": 1}),
+		decoded,
+		{Content: []any{"192.0.2.5"}, Enabled: true},
+		{Content: []any{"192.0.2.6"}, Meta: map[string]any{"cidr_labels": map[string]any{"asia": -1}}, Enabled: true},
+		{Content: []any{"192.0.2.7"}, Meta: map[string]any{"cidr_labels": map[string]any{"nowhere": 0}}},
+		labeledRecord("192.0.2.8", map[string]int{"nowhere": 0}),
+	}}
+
+	report := ValidateCidrLabels("www.example.com", rrset, mapping)
+	var codes []string
+	for _, f := range report.Findings {
+		codes = append(codes, f.Severity.String()+" "+f.Code+" "+f.Message)
+	}
+	assert.Equal(t, []string{
+		"error DNS009 record 2 192.0.2.3: cidr_labels apac is not a tag of mapping office",
+		"error DNS009 record 5 192.0.2.6: cidr_labels asia has invalid value -1",
+		"error DNS009 record 7 192.0.2.8: cidr_labels nowhere is not a tag of mapping office",
+		"warning DNS012 record 1 192.0.2.2 is never selected, no cidr_labels of it match",
+		"warning DNS011 tag eu of mapping office is never matched, its prefixes are overridden by longer ones",
+		"info DNS010 tag spare of mapping office is not used by records",
+	}, codes)
+	assert.Equal(t, []string{"spare"}, report.UnusedTags)
+	assert.Equal(t, []int{1}, report.Unreachable, "record with only unknown labels is not reported twice")
+	assert.Equal(t, "www.example.com", report.Findings[0].Name)
+	assert.Equal(t, "A", report.Findings[0].Type)
+
+	err := report.Err()
+	assert.True(t, errors.Is(err, ErrCidrLabels))
+	assert.ErrorContains(t, err, "apac is not a tag")
+
+	rrset.Records = rrset.Records[:2]
+	mapping.Mapping = mapping.Mapping[:3]
+	mapping.Mapping[1].CIDR4 = []IPNet{mustParseCIDR("10.0.0.0/10")}
+	report = ValidateCidrLabels("www.example.com", rrset, mapping)
+	assert.Empty(t, report.Findings)
+	assert.NoError(t, report.Err())
+}
+
+func TestClient_LinkNetworkMapping(t *testing.T) {
+	mux, client := setupTest(t)
+	ctx := context.Background()
+
+	mux.HandleFunc("/v2/network-mappings/office", handleJSONResponse(map[string]NetworkMappingResponse{
+		"network_mapping": {ID: 7, Name: "office", Mapping: mappingOf("eu", "10.0.0.0/8")}}))
+	rrset := RRSet{Type: "A", TTL: 300, Records: []ResourceRecord{labeledRecord("192.0.2.1", map[string]int{"eu": 0})}}
+	var updated []RRSet
+	mux.HandleFunc("/v2/zones/example.com/www.example.com/A", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPut {
+			var body RRSet
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			updated = append(updated, body)
+			return
+		}
+		handleJSONResponse(rrset)(rw, req)
+	})
+
+	report, err := client.LinkNetworkMapping(ctx, "example.com", "www.example.com", "A", "office",
+		LinkNetworkMappingOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Empty(t, report.Findings)
+	assert.Empty(t, updated)
+
+	_, err = client.LinkNetworkMapping(ctx, "example.com", "www.example.com", "A", "office", LinkNetworkMappingOptions{})
+	require.NoError(t, err)
+	require.Len(t, updated, 1)
+	assert.Equal(t, "office", updated[0].Meta["geodns_link"])
+	assert.Equal(t, 300, updated[0].TTL)
+
+	rrset.Records = append(rrset.Records, labeledRecord("192.0.2.2", map[string]int{"us": 0}))
+	_, err = client.LinkNetworkMapping(ctx, "example.com", "www.example.com", "A", "office", LinkNetworkMappingOptions{})
+	assert.True(t, errors.Is(err, ErrCidrLabels))
+	assert.Len(t, updated, 1)
+
+	report, err = client.LinkNetworkMapping(ctx, "example.com", "www.example.com", "A", "office",
+		LinkNetworkMappingOptions{Force: true})
+	require.NoError(t, err)
+	assert.Len(t, report.Findings, 1, "record with only unknown label is not unreachable")
+	assert.Len(t, updated, 2)
+
+	_, err = client.LinkNetworkMapping(ctx, "example.com", "www.example.com", "A", "missing", LinkNetworkMappingOptions{})
+	assert.ErrorContains(t, err, "get network mapping missing")
+}
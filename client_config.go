@@ -0,0 +1,386 @@
+package dnssdk
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// env variables read by LoadConfig
+const (
+	EnvConfigFile   = "GCORE_CONFIG_FILE"
+	EnvProfile      = "GCORE_PROFILE"
+	EnvAPIURL       = "GCORE_DNS_API_URL"
+	EnvAPIToken     = "GCORE_API_TOKEN"
+	EnvBearerToken  = "GCORE_API_BEARER_TOKEN"
+	EnvTokenFile    = "GCORE_API_TOKEN_FILE"
+	EnvUserAgent    = "GCORE_DNS_USER_AGENT"
+	EnvTimeout      = "GCORE_DNS_TIMEOUT"
+	EnvRetries      = "GCORE_DNS_RETRIES"
+	EnvRetryBackoff = "GCORE_DNS_RETRY_BACKOFF"
+	EnvProxy        = "GCORE_DNS_PROXY"
+	EnvDebug        = "GCORE_DNS_DEBUG"
+)
+
+// DefaultProfile used when neither profile argument nor GCORE_PROFILE is set
+const DefaultProfile = "default"
+
+// defaultRetryBackoff of WithRetries and PartialOptions when empty
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// ErrInvalidConfig wrapped by every error of config validation
+var ErrInvalidConfig = errors.New("invalid config")
+
+// config keys of profiles file, env variables are mapped to the same keys
+var configEnv = map[string]string{
+	"api_url":       EnvAPIURL,
+	"api_token":     EnvAPIToken,
+	"bearer_token":  EnvBearerToken,
+	"token_file":    EnvTokenFile,
+	"user_agent":    EnvUserAgent,
+	"timeout":       EnvTimeout,
+	"retries":       EnvRetries,
+	"retry_backoff": EnvRetryBackoff,
+	"proxy":         EnvProxy,
+	"debug":         EnvDebug,
+}
+
+// Config of Client.
+// Exactly one of APIToken, BearerToken and TokenFile authorizes requests.
+type Config struct {
+	APIURL      string
+	APIToken    string
+	BearerToken string
+	// TokenFile with permanent api key, reread when modified
+	TokenFile string
+	UserAgent string
+	// Timeout of every request, 10s when empty
+	Timeout time.Duration
+	// Retries of idempotent requests failed by network or 429, 5xx status
+	Retries      int
+	RetryBackoff time.Duration
+	// Proxy url, proxy from HTTP_PROXY/HTTPS_PROXY env when empty
+	Proxy string
+	Debug bool
+}
+
+// LoadConfig reads config with precedence from highest to lowest:
+//  1. env variables GCORE_DNS_API_URL, GCORE_API_TOKEN, GCORE_API_BEARER_TOKEN, GCORE_API_TOKEN_FILE,
+//     GCORE_DNS_USER_AGENT, GCORE_DNS_TIMEOUT, GCORE_DNS_RETRIES, GCORE_DNS_RETRY_BACKOFF, GCORE_DNS_PROXY, GCORE_DNS_DEBUG
+//  2. profile of profiles file, named by profile argument, GCORE_PROFILE env or "default"
+//  3. defaults
+//
+// Profiles file is GCORE_CONFIG_FILE or ~/.gcore/dns.yaml when it exists.
+// Files with .ini extension are read as INI with profile per section, others as YAML with profile per top level key.
+// Options passed to NewClientFromConfig override everything.
+func LoadConfig(profile string) (Config, error) {
+	cfg := Config{}
+
+	path, explicit := os.Getenv(EnvConfigFile), true
+	if path == "" {
+		explicit = false
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".gcore", "dns.yaml")
+		}
+	}
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+
+	if path != "" {
+		values, err := readProfile(path, profile)
+		switch {
+		case err == nil:
+			source := fmt.Sprintf("profile %s of %s", profileOrDefault(profile), path)
+			for key, value := range values {
+				if err = cfg.set(key, value); err != nil {
+					return Config{}, fmt.Errorf("%s: %w", source, err)
+				}
+			}
+		case errors.Is(err, os.ErrNotExist) && !explicit:
+		default:
+			return Config{}, err
+		}
+	}
+
+	if os.Getenv(EnvAPIToken)+os.Getenv(EnvBearerToken)+os.Getenv(EnvTokenFile) != "" {
+		// credentials of env replace credentials of profile
+		cfg.APIToken, cfg.BearerToken, cfg.TokenFile = "", "", ""
+	}
+	for key, env := range configEnv {
+		value, ok := os.LookupEnv(env)
+		if !ok || value == "" {
+			continue
+		}
+		if err := cfg.set(key, value); err != nil {
+			return Config{}, fmt.Errorf("env %s: %w", env, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// NewClientFromConfig constructor of Client by config, opts are applied last.
+func NewClientFromConfig(cfg Config, opts ...func(*Client)) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var credentials CredentialsProvider
+	switch {
+	case cfg.APIToken != "":
+		credentials = StaticAPIKey(cfg.APIToken)
+	case cfg.BearerToken != "":
+		credentials = StaticBearer(cfg.BearerToken)
+	default:
+		credentials = NewFileCredentials(cfg.TokenFile)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.Proxy != "" {
+		proxy, _ := url.Parse(cfg.Proxy)
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeOut
+	}
+
+	client := NewClientWithCredentials(credentials, func(client *Client) {
+		client.HTTPClient = &http.Client{Timeout: timeout, Transport: transport}
+		client.UserAgent = cfg.UserAgent
+		client.Debug = cfg.Debug
+		if cfg.APIURL != "" {
+			client.BaseURL, _ = url.Parse(cfg.APIURL)
+		}
+	})
+	if cfg.Retries > 0 {
+		WithRetries(cfg.Retries, cfg.RetryBackoff)(client)
+	}
+	for _, op := range opts {
+		op(client)
+	}
+
+	return client, nil
+}
+
+// Validate config
+func (c Config) Validate() error {
+	tokens := 0
+	for _, token := range []string{c.APIToken, c.BearerToken, c.TokenFile} {
+		if token != "" {
+			tokens++
+		}
+	}
+	switch {
+	case tokens == 0:
+		return fmt.Errorf("%w: one of api_token, bearer_token, token_file is required", ErrInvalidConfig)
+	case tokens > 1:
+		return fmt.Errorf("%w: only one of api_token, bearer_token, token_file is allowed", ErrInvalidConfig)
+	}
+	if c.APIURL != "" {
+		if err := validateHTTPURL(c.APIURL); err != nil {
+			return fmt.Errorf("%w: api_url: %v", ErrInvalidConfig, err)
+		}
+	}
+	if c.Proxy != "" {
+		if err := validateHTTPURL(c.Proxy); err != nil {
+			return fmt.Errorf("%w: proxy: %v", ErrInvalidConfig, err)
+		}
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("%w: timeout %s is negative", ErrInvalidConfig, c.Timeout)
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("%w: retries %d is negative", ErrInvalidConfig, c.Retries)
+	}
+	if c.RetryBackoff < 0 {
+		return fmt.Errorf("%w: retry_backoff %s is negative", ErrInvalidConfig, c.RetryBackoff)
+	}
+
+	return nil
+}
+
+func validateHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		// nolint: goerr113
+		return fmt.Errorf("%s is not http(s) url", raw)
+	}
+	return nil
+}
+
+func (c *Config) set(key, value string) error {
+	var err error
+	value = strings.TrimSpace(value)
+	switch key {
+	case "api_url":
+		c.APIURL = value
+	case "api_token":
+		c.APIToken = value
+	case "bearer_token":
+		c.BearerToken = value
+	case "token_file":
+		c.TokenFile = value
+	case "user_agent":
+		c.UserAgent = value
+	case "proxy":
+		c.Proxy = value
+	case "timeout":
+		c.Timeout, err = time.ParseDuration(value)
+	case "retry_backoff":
+		c.RetryBackoff, err = time.ParseDuration(value)
+	case "retries":
+		c.Retries, err = strconv.Atoi(value)
+	case "debug":
+		c.Debug, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("%w: unknown key %s", ErrInvalidConfig, key)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, key, err)
+	}
+	return nil
+}
+
+func profileOrDefault(profile string) string {
+	if profile == "" {
+		return DefaultProfile
+	}
+	return profile
+}
+
+// readProfile key values of profile from file, missing default profile is empty
+func readProfile(path, profile string) (map[string]string, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var profiles map[string]map[string]string
+	if strings.EqualFold(filepath.Ext(path), ".ini") {
+		profiles, err = parseINI(bs)
+	} else {
+		err = yaml.Unmarshal(bs, &profiles)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse %s: %v", ErrInvalidConfig, path, err)
+	}
+
+	values, ok := profiles[profileOrDefault(profile)]
+	if !ok && profile != "" {
+		return nil, fmt.Errorf("%w: profile %s not found in %s", ErrInvalidConfig, profile, path)
+	}
+	return values, nil
+}
+
+// parseINI sections with key = value lines, ; and # start comments
+func parseINI(bs []byte) (map[string]map[string]string, error) {
+	res := map[string]map[string]string{}
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(bs))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if res[section] == nil {
+				res[section] = map[string]string{}
+			}
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok || section == "" {
+				// nolint: goerr113
+				return nil, fmt.Errorf("line %d: key = value in [section] expected", n)
+			}
+			res[section][strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WithRetries retries idempotent requests failed by network error or 429, 5xx status
+// with linear backoff, 500ms when empty.
+func WithRetries(retries int, backoff time.Duration) func(*Client) {
+	return func(client *Client) {
+		if backoff == 0 {
+			backoff = defaultRetryBackoff
+		}
+		next := client.HTTPClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		client.HTTPClient.Transport = retryTransport{next: next, retries: retries, backoff: backoff}
+	}
+}
+
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+	backoff time.Duration
+}
+
+// RoundTrip implementation of http.RoundTripper
+func (r retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead ||
+		req.Method == http.MethodPut || req.Method == http.MethodDelete
+	if !idempotent || (req.Body != nil && req.GetBody == nil) {
+		return r.next.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := r.next.RoundTrip(req)
+		if attempt >= r.retries || !retryableResponse(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(r.backoff * time.Duration(attempt+1))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			clone := req.Clone(req.Context())
+			if clone.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+			req = clone
+		}
+	}
+}
+
+func retryableResponse(resp *http.Response, err error) bool {
+	return err != nil || retryableStatus(resp.StatusCode)
+}
+
+// retryableStatus for 429 and 5xx, shared by WithRetries and PartialOptions
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
@@ -0,0 +1,240 @@
+package dnssdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearConfigEnv so tests do not depend on environment of developer
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+	for _, env := range append([]string{EnvConfigFile, EnvProfile}, mapValues(configEnv)...) {
+		t.Setenv(env, "")
+	}
+}
+
+func mapValues(m map[string]string) []string {
+	res := make([]string, 0, len(m))
+	for _, v := range m {
+		res = append(res, v)
+	}
+	return res
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(EnvConfigFile, path)
+	return path
+}
+
+const testConfigYAML = `
+default:
+  api_token: default-token
+  timeout: 5s
+reseller:
+  api_url: https://dns.example.com/api
+  bearer_token: reseller-jwt
+  retries: 3
+  retry_backoff: 1s
+  proxy: http://proxy.example.com:3128
+  debug: true
+  user_agent: tool/1.0
+`
+
+func TestLoadConfig_yamlProfiles(t *testing.T) {
+	clearConfigEnv(t)
+	writeConfig(t, "dns.yaml", testConfigYAML)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, Config{APIToken: "default-token", Timeout: 5 * time.Second}, cfg)
+
+	cfg, err = LoadConfig("reseller")
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		APIURL:       "https://dns.example.com/api",
+		BearerToken:  "reseller-jwt",
+		UserAgent:    "tool/1.0",
+		Retries:      3,
+		RetryBackoff: time.Second,
+		Proxy:        "http://proxy.example.com:3128",
+		Debug:        true,
+	}, cfg)
+
+	t.Setenv(EnvProfile, "reseller")
+	cfg, err = LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "reseller-jwt", cfg.BearerToken)
+
+	_, err = LoadConfig("missing")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestLoadConfig_ini(t *testing.T) {
+	clearConfigEnv(t)
+	writeConfig(t, "dns.ini", `
+; comment
+[default]
+api_token = "ini-token"
+retries = 2
+
+[other]
+token_file = /run/secrets/gcore
+`)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, Config{APIToken: "ini-token", Retries: 2}, cfg)
+
+	cfg, err = LoadConfig("other")
+	require.NoError(t, err)
+	assert.Equal(t, "/run/secrets/gcore", cfg.TokenFile)
+}
+
+func TestLoadConfig_envOverridesProfile(t *testing.T) {
+	clearConfigEnv(t)
+	writeConfig(t, "dns.yaml", testConfigYAML)
+	t.Setenv(EnvTimeout, "30s")
+	t.Setenv(EnvDebug, "true")
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, Config{APIToken: "default-token", Timeout: 30 * time.Second, Debug: true}, cfg)
+
+	t.Setenv(EnvBearerToken, "env-jwt")
+	cfg, err = LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, Config{BearerToken: "env-jwt", Timeout: 30 * time.Second, Debug: true}, cfg)
+}
+
+func TestLoadConfig_envOnly(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvAPIToken, "env-token")
+	t.Setenv(EnvAPIURL, "https://dns.example.com")
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, Config{APIToken: "env-token", APIURL: "https://dns.example.com"}, cfg)
+}
+
+func TestLoadConfig_errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		config string
+	}{
+		{name: "no token"},
+		{name: "two tokens", env: map[string]string{EnvAPIToken: "a", EnvBearerToken: "b"}},
+		{name: "bad timeout", env: map[string]string{EnvAPIToken: "a", EnvTimeout: "5"}},
+		{name: "negative retries", env: map[string]string{EnvAPIToken: "a", EnvRetries: "-1"}},
+		{name: "bad debug", env: map[string]string{EnvAPIToken: "a", EnvDebug: "yes please"}},
+		{name: "bad url", env: map[string]string{EnvAPIToken: "a", EnvAPIURL: "dns.example.com"}},
+		{name: "bad proxy", env: map[string]string{EnvAPIToken: "a", EnvProxy: "ftp://proxy"}},
+		{name: "unknown key", config: "default:\n  api_tokn: a\n"},
+		{name: "broken yaml", config: "default: [\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			clearConfigEnv(t)
+			if tt.config != "" {
+				writeConfig(t, "dns.yaml", tt.config)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := LoadConfig("")
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestLoadConfig_missingExplicitFile(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvAPIToken, "a")
+	t.Setenv(EnvConfigFile, filepath.Join(t.TempDir(), "missing.yaml"))
+
+	_, err := LoadConfig("")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestNewClientFromConfig(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// first attempt fails
+		if atomic.AddInt32(&calls, 1) == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if req.URL.Path != "/api/v2/zones/example.com" || req.Header.Get("Authorization") != "APIKey token" ||
+			req.Header.Get("User-Agent") != "tool/1.0" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		handleJSONResponse(Zone{Name: "example.com"})(rw, req)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromConfig(Config{
+		APIURL:       server.URL + "/api",
+		APIToken:     "token",
+		UserAgent:    "tool/1.0",
+		Timeout:      time.Second,
+		Retries:      1,
+		RetryBackoff: time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, client.HTTPClient.Timeout)
+
+	zone, err := client.Zone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	_, err = NewClientFromConfig(Config{})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestWithRetries_notIdempotent(t *testing.T) {
+	var calls int32
+	mux, client := setupTest(t)
+	WithRetries(3, time.Millisecond)(client)
+
+	mux.HandleFunc("/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.CreateZone(context.Background(), AddZone{Name: "example.com"})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryableStatus(t *testing.T) {
+	for code, want := range map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusGatewayTimeout:      true,
+		http.StatusBadRequest:          false,
+		http.StatusNotFound:            false,
+	} {
+		assert.Equal(t, want, retryableResponse(&http.Response{StatusCode: code}, nil), code)
+		assert.Equal(t, want, retryable(APIError{StatusCode: code}), code)
+	}
+}
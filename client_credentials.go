@@ -0,0 +1,222 @@
+package dnssdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultRefreshBefore = time.Minute
+
+// CredentialsProvider gives Authorization header for every request
+type CredentialsProvider interface {
+	// AuthHeader returns value of Authorization header
+	AuthHeader(ctx context.Context) (string, error)
+	// Invalidate drops cached credentials, it is called once on 401 response before retry
+	Invalidate()
+}
+
+// WithCredentials authorizes requests by provider instead of authorizer of NewClient.
+// On 401 response credentials are invalidated and the request is retried once.
+func WithCredentials(provider CredentialsProvider) func(*Client) {
+	return func(client *Client) {
+		client.credentials = provider
+	}
+}
+
+// NewClientWithCredentials constructor of Client authorized by provider.
+func NewClientWithCredentials(provider CredentialsProvider, opts ...func(*Client)) *Client {
+	return NewClient(nil, append([]func(*Client){WithCredentials(provider)}, opts...)...)
+}
+
+// StaticCredentials fixed Authorization header
+type StaticCredentials string
+
+// AuthHeader implementation of CredentialsProvider
+func (s StaticCredentials) AuthHeader(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// Invalidate implementation of CredentialsProvider
+func (s StaticCredentials) Invalidate() {}
+
+// StaticAPIKey permanent api key credentials
+func StaticAPIKey(token string) StaticCredentials {
+	return StaticCredentials(fmt.Sprintf("%s %s", tokenHeader, token))
+}
+
+// StaticBearer bearer token credentials
+func StaticBearer(token string) StaticCredentials {
+	return StaticCredentials(fmt.Sprintf("Bearer %s", token))
+}
+
+// EnvCredentials reads permanent api key from environment variable on every request
+type EnvCredentials struct {
+	Name string
+}
+
+// AuthHeader implementation of CredentialsProvider
+func (e EnvCredentials) AuthHeader(context.Context) (string, error) {
+	token := strings.TrimSpace(os.Getenv(e.Name))
+	if token == "" {
+		// nolint: goerr113
+		return "", fmt.Errorf("env %s is empty", e.Name)
+	}
+	return fmt.Sprintf("%s %s", tokenHeader, token), nil
+}
+
+// Invalidate implementation of CredentialsProvider
+func (e EnvCredentials) Invalidate() {}
+
+// FileCredentials reads permanent api key from file and rereads it when file is modified
+type FileCredentials struct {
+	path    string
+	mu      sync.Mutex
+	modTime time.Time
+	header  string
+}
+
+// NewFileCredentials for key stored in file, e.g. mounted secret
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path}
+}
+
+// AuthHeader implementation of CredentialsProvider
+func (f *FileCredentials) AuthHeader(context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("credentials file: %w", err)
+	}
+	if f.header != "" && info.ModTime().Equal(f.modTime) {
+		return f.header, nil
+	}
+	bs, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("credentials file: %w", err)
+	}
+	token := strings.TrimSpace(string(bs))
+	if token == "" {
+		// nolint: goerr113
+		return "", fmt.Errorf("credentials file %s is empty", f.path)
+	}
+	f.header = fmt.Sprintf("%s %s", tokenHeader, token)
+	f.modTime = info.ModTime()
+	return f.header, nil
+}
+
+// Invalidate implementation of CredentialsProvider
+func (f *FileCredentials) Invalidate() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.header = ""
+}
+
+// OAuthConfig of client credentials grant to token endpoint
+type OAuthConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// RefreshBefore expiry token is fetched again, 1 minute when empty
+	RefreshBefore time.Duration
+	// HTTPClient to call token endpoint, http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// OAuthCredentials bearer token fetched from token endpoint and refreshed before expiry
+type OAuthCredentials struct {
+	cfg     OAuthConfig
+	now     func() time.Time
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewOAuthCredentials for bearer token of client credentials grant
+func NewOAuthCredentials(cfg OAuthConfig) *OAuthCredentials {
+	if cfg.RefreshBefore == 0 {
+		cfg.RefreshBefore = defaultRefreshBefore
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &OAuthCredentials{cfg: cfg, now: time.Now}
+}
+
+// AuthHeader implementation of CredentialsProvider
+func (o *OAuthCredentials) AuthHeader(ctx context.Context) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.token != "" && (o.expires.IsZero() || o.now().Add(o.cfg.RefreshBefore).Before(o.expires)) {
+		return "Bearer " + o.token, nil
+	}
+	if err := o.fetch(ctx); err != nil {
+		return "", err
+	}
+	return "Bearer " + o.token, nil
+}
+
+// Invalidate implementation of CredentialsProvider
+func (o *OAuthCredentials) Invalidate() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.token = ""
+}
+
+// tokenResponse of token endpoint https://www.rfc-editor.org/rfc/rfc6749#section-5.1
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (o *OAuthCredentials) fetch(ctx context.Context) error {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(o.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(o.cfg.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(o.cfg.ClientID), url.QueryEscape(o.cfg.ClientSecret))
+
+	resp, err := o.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("token request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		// nolint: goerr113
+		return fmt.Errorf("token request: status %d", resp.StatusCode)
+	}
+	var token tokenResponse
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		// nolint: goerr113
+		return fmt.Errorf("token response: access_token is empty")
+	}
+	o.token = token.AccessToken
+	o.expires = time.Time{}
+	if token.ExpiresIn > 0 {
+		o.expires = o.now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return nil
+}
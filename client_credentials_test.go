@@ -0,0 +1,167 @@
+package dnssdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	header, err := StaticAPIKey("key").AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "APIKey key", header)
+
+	header, err = StaticBearer("jwt").AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer jwt", header)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	ctx := context.Background()
+	provider := EnvCredentials{Name: "GCORE_TEST_CREDENTIALS"}
+
+	t.Setenv("GCORE_TEST_CREDENTIALS", "")
+	_, err := provider.AuthHeader(ctx)
+	assert.Error(t, err)
+
+	t.Setenv("GCORE_TEST_CREDENTIALS", " key\n")
+	header, err := provider.AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "APIKey key", header)
+}
+
+func TestFileCredentials_rotation(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "token")
+	provider := NewFileCredentials(path)
+
+	_, err := provider.AuthHeader(ctx)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+	header, err := provider.AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "APIKey first", header)
+
+	require.NoError(t, os.WriteFile(path, []byte("second\n"), 0o600))
+	// mtime resolution of some file systems is coarse
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	header, err = provider.AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "APIKey second", header)
+}
+
+func tokenServer(t *testing.T, expiresIn int64) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		id, secret, ok := req.BasicAuth()
+		if req.Method != http.MethodPost || !ok || id != "client" || secret != "secret" ||
+			req.FormValue("grant_type") != "client_credentials" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		n := atomic.AddInt32(&issued, 1)
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(rw, `{"access_token":"token%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &issued
+}
+
+func TestOAuthCredentials_refresh(t *testing.T) {
+	server, issued := tokenServer(t, 3600)
+	ctx := context.Background()
+
+	provider := NewOAuthCredentials(OAuthConfig{TokenURL: server.URL, ClientID: "client", ClientSecret: "secret"})
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	header, err := provider.AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token1", header)
+
+	header, err = provider.AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token1", header)
+
+	// within RefreshBefore of expiry
+	now = now.Add(time.Hour - 30*time.Second)
+	header, err = provider.AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token2", header)
+	assert.Equal(t, int32(2), atomic.LoadInt32(issued))
+
+	provider.Invalidate()
+	header, err = provider.AuthHeader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token3", header)
+}
+
+func TestOAuthCredentials_wrongSecret(t *testing.T) {
+	server, _ := tokenServer(t, 3600)
+
+	provider := NewOAuthCredentials(OAuthConfig{TokenURL: server.URL, ClientID: "client", ClientSecret: "wrong"})
+	_, err := provider.AuthHeader(context.Background())
+	assert.Error(t, err)
+}
+
+func TestClient_WithCredentials_reauthOn401(t *testing.T) {
+	tokens, issued := tokenServer(t, 3600)
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	// first issued token is revoked on api side
+	mux.HandleFunc("/v2/zones/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token2" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = rw.Write([]byte(`{"error":"token is revoked"}`))
+			return
+		}
+		handleJSONResponse(Zone{Name: "example.com"})(rw, req)
+	})
+
+	client := NewClientWithCredentials(NewOAuthCredentials(OAuthConfig{
+		TokenURL: tokens.URL, ClientID: "client", ClientSecret: "secret",
+	}))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	zone, err := client.Zone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+	assert.Equal(t, int32(2), atomic.LoadInt32(issued))
+}
+
+func TestClient_WithCredentials_retryOnce(t *testing.T) {
+	var calls int32
+	mux, client := setupTest(t)
+	WithCredentials(StaticAPIKey("wrong"))(client)
+
+	mux.HandleFunc("/v2/zones/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusUnauthorized)
+		_, _ = rw.Write([]byte(`{"error":"invalid token"}`))
+	})
+
+	_, err := client.Zone(context.Background(), "example.com")
+	var apiErr APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,455 @@
+package dnssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ErrReadOnly returned by write methods of ReadOnly decorator
+var ErrReadOnly = errors.New("read-only client")
+
+// Logger to print decorators messages, log.Printf fits
+type Logger func(format string, args ...interface{})
+
+// ReadOnly decorates API to reject every write with ErrReadOnly
+func ReadOnly(next API) API {
+	return readOnlyAPI{API: next}
+}
+
+type readOnlyAPI struct {
+	API
+}
+
+func (r readOnlyAPI) CreateZone(_ context.Context, addZone AddZone) (uint64, error) {
+	return 0, fmt.Errorf("create zone %s: %w", addZone.Name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) UpdateZone(_ context.Context, name string, _ AddZone) (uint64, error) {
+	return 0, fmt.Errorf("update zone %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) DeleteZone(_ context.Context, name string) error {
+	return fmt.Errorf("delete zone %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) EnableZone(_ context.Context, name string) error {
+	return fmt.Errorf("enable zone %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) DisableZone(_ context.Context, name string) error {
+	return fmt.Errorf("disable zone %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) ImportZone(_ context.Context, name, _ string) (ImportZoneResponse, error) {
+	return ImportZoneResponse{}, fmt.Errorf("import zone %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) CreateRRSet(_ context.Context, zone, name, recordType string, _ RRSet) error {
+	return fmt.Errorf("create rrset %s %s %s: %w", zone, name, recordType, ErrReadOnly)
+}
+
+func (r readOnlyAPI) UpdateRRSet(_ context.Context, zone, name, recordType string, _ RRSet) error {
+	return fmt.Errorf("update rrset %s %s %s: %w", zone, name, recordType, ErrReadOnly)
+}
+
+func (r readOnlyAPI) DeleteRRSet(_ context.Context, zone, name, recordType string) error {
+	return fmt.Errorf("delete rrset %s %s %s: %w", zone, name, recordType, ErrReadOnly)
+}
+
+func (r readOnlyAPI) DeleteRRSetRecord(_ context.Context, zone, name, recordType string, _ ...string) error {
+	return fmt.Errorf("delete rrset record %s %s %s: %w", zone, name, recordType, ErrReadOnly)
+}
+
+func (r readOnlyAPI) AddZoneRRSet(_ context.Context, zone, recordName, recordType string,
+	_ []ResourceRecord, _ int, _ ...AddZoneOpt) error {
+	return fmt.Errorf("add rrset %s %s %s: %w", zone, recordName, recordType, ErrReadOnly)
+}
+
+func (r readOnlyAPI) ToggleDnssec(_ context.Context, zone string, _ bool) (DNSSecDS, error) {
+	return DNSSecDS{}, fmt.Errorf("toggle dnssec %s: %w", zone, ErrReadOnly)
+}
+
+func (r readOnlyAPI) CreateNetworkMapping(_ context.Context, mapping NetworkMappingRequest) (uint64, error) {
+	return 0, fmt.Errorf("create network mapping %s: %w", mapping.Name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) UpdateNetworkMapping(_ context.Context, id uint64, _ NetworkMappingRequest) error {
+	return fmt.Errorf("update network mapping %d: %w", id, ErrReadOnly)
+}
+
+func (r readOnlyAPI) DeleteNetworkMapping(_ context.Context, id uint64) error {
+	return fmt.Errorf("delete network mapping %d: %w", id, ErrReadOnly)
+}
+
+func (r readOnlyAPI) CreateSecondaryZone(_ context.Context, zone AddSecondaryZone) (uint64, error) {
+	return 0, fmt.Errorf("create secondary zone %s: %w", zone.Name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) UpdateSecondaryZone(_ context.Context, name string, _ AddSecondaryZone) error {
+	return fmt.Errorf("update secondary zone %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) DeleteSecondaryZone(_ context.Context, name string) error {
+	return fmt.Errorf("delete secondary zone %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) RetransferSecondaryZone(_ context.Context, name string) error {
+	return fmt.Errorf("retransfer %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) CreateTSIGKey(_ context.Context, key TSIGKey) (uint64, error) {
+	return 0, fmt.Errorf("create tsig key %s: %w", key.Name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) UpdateTSIGKey(_ context.Context, name string, _ TSIGKey) error {
+	return fmt.Errorf("update tsig key %s: %w", name, ErrReadOnly)
+}
+
+func (r readOnlyAPI) DeleteTSIGKey(_ context.Context, name string) error {
+	return fmt.Errorf("delete tsig key %s: %w", name, ErrReadOnly)
+}
+
+// DryRun decorates API to log writes instead of sending them, reads are passed through.
+// Writes return zero values, Client with WithDryRun option records exact payloads and returns synthetic results.
+func DryRun(next API, logger Logger) API {
+	if logger == nil {
+		logger = log.Printf
+	}
+	return dryRunAPI{API: next, log: logger}
+}
+
+type dryRunAPI struct {
+	API
+	log Logger
+}
+
+func (d dryRunAPI) CreateZone(_ context.Context, addZone AddZone) (uint64, error) {
+	d.log("[DRY-RUN] create zone %s %+v", addZone.Name, addZone)
+	return 0, nil
+}
+
+func (d dryRunAPI) UpdateZone(_ context.Context, name string, updateZone AddZone) (uint64, error) {
+	d.log("[DRY-RUN] update zone %s %+v", name, updateZone)
+	return 0, nil
+}
+
+func (d dryRunAPI) DeleteZone(_ context.Context, name string) error {
+	d.log("[DRY-RUN] delete zone %s", name)
+	return nil
+}
+
+func (d dryRunAPI) EnableZone(_ context.Context, name string) error {
+	d.log("[DRY-RUN] enable zone %s", name)
+	return nil
+}
+
+func (d dryRunAPI) DisableZone(_ context.Context, name string) error {
+	d.log("[DRY-RUN] disable zone %s", name)
+	return nil
+}
+
+func (d dryRunAPI) ImportZone(_ context.Context, name, content string) (ImportZoneResponse, error) {
+	d.log("[DRY-RUN] import zone %s %d bytes", name, len(content))
+	return ImportZoneResponse{}, nil
+}
+
+func (d dryRunAPI) CreateRRSet(_ context.Context, zone, name, recordType string, record RRSet) error {
+	d.log("[DRY-RUN] create rrset %s %s %s %+v", zone, name, recordType, record)
+	return nil
+}
+
+func (d dryRunAPI) UpdateRRSet(_ context.Context, zone, name, recordType string, record RRSet) error {
+	d.log("[DRY-RUN] update rrset %s %s %s %+v", zone, name, recordType, record)
+	return nil
+}
+
+func (d dryRunAPI) DeleteRRSet(_ context.Context, zone, name, recordType string) error {
+	d.log("[DRY-RUN] delete rrset %s %s %s", zone, name, recordType)
+	return nil
+}
+
+func (d dryRunAPI) DeleteRRSetRecord(_ context.Context, zone, name, recordType string, contents ...string) error {
+	d.log("[DRY-RUN] delete rrset record %s %s %s %q", zone, name, recordType, contents)
+	return nil
+}
+
+func (d dryRunAPI) AddZoneRRSet(_ context.Context, zone, recordName, recordType string,
+	values []ResourceRecord, ttl int, _ ...AddZoneOpt) error {
+	d.log("[DRY-RUN] add rrset %s %s %s ttl=%d %+v", zone, recordName, recordType, ttl, values)
+	return nil
+}
+
+func (d dryRunAPI) ToggleDnssec(_ context.Context, zone string, enable bool) (DNSSecDS, error) {
+	d.log("[DRY-RUN] toggle dnssec %s enabled=%t", zone, enable)
+	return DNSSecDS{}, nil
+}
+
+func (d dryRunAPI) CreateNetworkMapping(_ context.Context, mapping NetworkMappingRequest) (uint64, error) {
+	d.log("[DRY-RUN] create network mapping %s %+v", mapping.Name, mapping)
+	return 0, nil
+}
+
+func (d dryRunAPI) UpdateNetworkMapping(_ context.Context, id uint64, mapping NetworkMappingRequest) error {
+	d.log("[DRY-RUN] update network mapping %d %+v", id, mapping)
+	return nil
+}
+
+func (d dryRunAPI) DeleteNetworkMapping(_ context.Context, id uint64) error {
+	d.log("[DRY-RUN] delete network mapping %d", id)
+	return nil
+}
+
+func (d dryRunAPI) CreateSecondaryZone(_ context.Context, zone AddSecondaryZone) (uint64, error) {
+	d.log("[DRY-RUN] create secondary zone %s %+v", zone.Name, zone)
+	return 0, nil
+}
+
+func (d dryRunAPI) UpdateSecondaryZone(_ context.Context, name string, zone AddSecondaryZone) error {
+	d.log("[DRY-RUN] update secondary zone %s %+v", name, zone)
+	return nil
+}
+
+func (d dryRunAPI) DeleteSecondaryZone(_ context.Context, name string) error {
+	d.log("[DRY-RUN] delete secondary zone %s", name)
+	return nil
+}
+
+func (d dryRunAPI) RetransferSecondaryZone(_ context.Context, name string) error {
+	d.log("[DRY-RUN] retransfer %s", name)
+	return nil
+}
+
+func (d dryRunAPI) CreateTSIGKey(_ context.Context, key TSIGKey) (uint64, error) {
+	d.log("[DRY-RUN] create tsig key %s %s", key.Name, key.Algorithm)
+	return 0, nil
+}
+
+func (d dryRunAPI) UpdateTSIGKey(_ context.Context, name string, key TSIGKey) error {
+	d.log("[DRY-RUN] update tsig key %s %s", name, key.Algorithm)
+	return nil
+}
+
+func (d dryRunAPI) DeleteTSIGKey(_ context.Context, name string) error {
+	d.log("[DRY-RUN] delete tsig key %s", name)
+	return nil
+}
+
+// Logging decorates API to log every call with its duration and error
+func Logging(next API, logger Logger) API {
+	if logger == nil {
+		logger = log.Printf
+	}
+	return loggingAPI{next: next, log: logger}
+}
+
+type loggingAPI struct {
+	next API
+	log  Logger
+}
+
+func (l loggingAPI) done(method string, start time.Time, err error) {
+	if err != nil {
+		l.log("[DNS] %s failed in %s: %v", method, time.Since(start), err)
+		return
+	}
+	l.log("[DNS] %s done in %s", method, time.Since(start))
+}
+
+func (l loggingAPI) CreateZone(ctx context.Context, addZone AddZone) (id uint64, err error) {
+	defer func(start time.Time) { l.done("CreateZone "+addZone.Name, start, err) }(time.Now())
+	return l.next.CreateZone(ctx, addZone)
+}
+
+func (l loggingAPI) UpdateZone(ctx context.Context, name string, updateZone AddZone) (id uint64, err error) {
+	defer func(start time.Time) { l.done("UpdateZone "+name, start, err) }(time.Now())
+	return l.next.UpdateZone(ctx, name, updateZone)
+}
+
+func (l loggingAPI) Zones(ctx context.Context, filters ...func(zone *ZonesFilter)) (zones []Zone, err error) {
+	defer func(start time.Time) { l.done("Zones", start, err) }(time.Now())
+	return l.next.Zones(ctx, filters...)
+}
+
+func (l loggingAPI) ZonesWithParam(ctx context.Context, param ZonesParam) (res ListZones, err error) {
+	defer func(start time.Time) { l.done("ZonesWithParam", start, err) }(time.Now())
+	return l.next.ZonesWithParam(ctx, param)
+}
+
+func (l loggingAPI) AllZones(ctx context.Context, nameFilters []string) (zones []Zone, err error) {
+	defer func(start time.Time) { l.done("AllZones", start, err) }(time.Now())
+	return l.next.AllZones(ctx, nameFilters)
+}
+
+func (l loggingAPI) ZonesWithRecords(ctx context.Context, filters ...func(zone *ZonesFilter)) (zones []Zone, err error) {
+	defer func(start time.Time) { l.done("ZonesWithRecords", start, err) }(time.Now())
+	return l.next.ZonesWithRecords(ctx, filters...)
+}
+
+func (l loggingAPI) AllZonesWithRecords(ctx context.Context, nameFilters []string) (zones []Zone, err error) {
+	defer func(start time.Time) { l.done("AllZonesWithRecords", start, err) }(time.Now())
+	return l.next.AllZonesWithRecords(ctx, nameFilters)
+}
+
+func (l loggingAPI) DeleteZone(ctx context.Context, name string) (err error) {
+	defer func(start time.Time) { l.done("DeleteZone "+name, start, err) }(time.Now())
+	return l.next.DeleteZone(ctx, name)
+}
+
+func (l loggingAPI) Zone(ctx context.Context, name string) (zone Zone, err error) {
+	defer func(start time.Time) { l.done("Zone "+name, start, err) }(time.Now())
+	return l.next.Zone(ctx, name)
+}
+
+func (l loggingAPI) ZoneNameservers(ctx context.Context, name string) (ns []string, err error) {
+	defer func(start time.Time) { l.done("ZoneNameservers "+name, start, err) }(time.Now())
+	return l.next.ZoneNameservers(ctx, name)
+}
+
+func (l loggingAPI) EnableZone(ctx context.Context, name string) (err error) {
+	defer func(start time.Time) { l.done("EnableZone "+name, start, err) }(time.Now())
+	return l.next.EnableZone(ctx, name)
+}
+
+func (l loggingAPI) DisableZone(ctx context.Context, name string) (err error) {
+	defer func(start time.Time) { l.done("DisableZone "+name, start, err) }(time.Now())
+	return l.next.DisableZone(ctx, name)
+}
+
+func (l loggingAPI) ImportZone(ctx context.Context, name, content string) (res ImportZoneResponse, err error) {
+	defer func(start time.Time) { l.done("ImportZone "+name, start, err) }(time.Now())
+	return l.next.ImportZone(ctx, name, content)
+}
+
+func (l loggingAPI) ZoneRRSets(ctx context.Context, zone string) (res []ZoneRRSet, err error) {
+	defer func(start time.Time) { l.done("ZoneRRSets "+zone, start, err) }(time.Now())
+	return l.next.ZoneRRSets(ctx, zone)
+}
+
+func (l loggingAPI) RRSet(ctx context.Context, zone, name, recordType string, limit, offset int) (res RRSet, err error) {
+	defer func(start time.Time) { l.done(fmt.Sprintf("RRSet %s %s %s", zone, name, recordType), start, err) }(time.Now())
+	return l.next.RRSet(ctx, zone, name, recordType, limit, offset)
+}
+
+func (l loggingAPI) CreateRRSet(ctx context.Context, zone, name, recordType string, record RRSet) (err error) {
+	defer func(start time.Time) { l.done(fmt.Sprintf("CreateRRSet %s %s %s", zone, name, recordType), start, err) }(time.Now())
+	return l.next.CreateRRSet(ctx, zone, name, recordType, record)
+}
+
+func (l loggingAPI) UpdateRRSet(ctx context.Context, zone, name, recordType string, record RRSet) (err error) {
+	defer func(start time.Time) { l.done(fmt.Sprintf("UpdateRRSet %s %s %s", zone, name, recordType), start, err) }(time.Now())
+	return l.next.UpdateRRSet(ctx, zone, name, recordType, record)
+}
+
+func (l loggingAPI) DeleteRRSet(ctx context.Context, zone, name, recordType string) (err error) {
+	defer func(start time.Time) { l.done(fmt.Sprintf("DeleteRRSet %s %s %s", zone, name, recordType), start, err) }(time.Now())
+	return l.next.DeleteRRSet(ctx, zone, name, recordType)
+}
+
+func (l loggingAPI) DeleteRRSetRecord(ctx context.Context, zone, name, recordType string, contents ...string) (err error) {
+	defer func(start time.Time) {
+		l.done(fmt.Sprintf("DeleteRRSetRecord %s %s %s", zone, name, recordType), start, err)
+	}(time.Now())
+	return l.next.DeleteRRSetRecord(ctx, zone, name, recordType, contents...)
+}
+
+func (l loggingAPI) AddZoneRRSet(ctx context.Context, zone, recordName, recordType string,
+	values []ResourceRecord, ttl int, opts ...AddZoneOpt) (err error) {
+	defer func(start time.Time) {
+		l.done(fmt.Sprintf("AddZoneRRSet %s %s %s", zone, recordName, recordType), start, err)
+	}(time.Now())
+	return l.next.AddZoneRRSet(ctx, zone, recordName, recordType, values, ttl, opts...)
+}
+
+func (l loggingAPI) DNSSecDS(ctx context.Context, zone string) (res DNSSecDS, err error) {
+	defer func(start time.Time) { l.done("DNSSecDS "+zone, start, err) }(time.Now())
+	return l.next.DNSSecDS(ctx, zone)
+}
+
+func (l loggingAPI) ToggleDnssec(ctx context.Context, zone string, enable bool) (res DNSSecDS, err error) {
+	defer func(start time.Time) { l.done(fmt.Sprintf("ToggleDnssec %s %t", zone, enable), start, err) }(time.Now())
+	return l.next.ToggleDnssec(ctx, zone, enable)
+}
+
+func (l loggingAPI) ListNetworkMappings(ctx context.Context,
+	params NetworkMappingsParams) (res *ListNetworkMappingResponse, err error) {
+	defer func(start time.Time) { l.done("ListNetworkMappings", start, err) }(time.Now())
+	return l.next.ListNetworkMappings(ctx, params)
+}
+
+func (l loggingAPI) CreateNetworkMapping(ctx context.Context, mapping NetworkMappingRequest) (id uint64, err error) {
+	defer func(start time.Time) { l.done("CreateNetworkMapping "+mapping.Name, start, err) }(time.Now())
+	return l.next.CreateNetworkMapping(ctx, mapping)
+}
+
+func (l loggingAPI) GetNetworkMapping(ctx context.Context, id uint64) (res *NetworkMappingResponse, err error) {
+	defer func(start time.Time) { l.done(fmt.Sprintf("GetNetworkMapping %d", id), start, err) }(time.Now())
+	return l.next.GetNetworkMapping(ctx, id)
+}
+
+func (l loggingAPI) GetNetworkMappingByName(ctx context.Context, name string) (res *NetworkMappingResponse, err error) {
+	defer func(start time.Time) { l.done("GetNetworkMappingByName "+name, start, err) }(time.Now())
+	return l.next.GetNetworkMappingByName(ctx, name)
+}
+
+func (l loggingAPI) UpdateNetworkMapping(ctx context.Context, id uint64, mapping NetworkMappingRequest) (err error) {
+	defer func(start time.Time) { l.done(fmt.Sprintf("UpdateNetworkMapping %d", id), start, err) }(time.Now())
+	return l.next.UpdateNetworkMapping(ctx, id, mapping)
+}
+
+func (l loggingAPI) DeleteNetworkMapping(ctx context.Context, id uint64) (err error) {
+	defer func(start time.Time) { l.done(fmt.Sprintf("DeleteNetworkMapping %d", id), start, err) }(time.Now())
+	return l.next.DeleteNetworkMapping(ctx, id)
+}
+
+func (l loggingAPI) CreateSecondaryZone(ctx context.Context, zone AddSecondaryZone) (id uint64, err error) {
+	defer func(start time.Time) { l.done("CreateSecondaryZone "+zone.Name, start, err) }(time.Now())
+	return l.next.CreateSecondaryZone(ctx, zone)
+}
+
+func (l loggingAPI) UpdateSecondaryZone(ctx context.Context, name string, zone AddSecondaryZone) (err error) {
+	defer func(start time.Time) { l.done("UpdateSecondaryZone "+name, start, err) }(time.Now())
+	return l.next.UpdateSecondaryZone(ctx, name, zone)
+}
+
+func (l loggingAPI) SecondaryZone(ctx context.Context, name string) (zone SecondaryZone, err error) {
+	defer func(start time.Time) { l.done("SecondaryZone "+name, start, err) }(time.Now())
+	return l.next.SecondaryZone(ctx, name)
+}
+
+func (l loggingAPI) DeleteSecondaryZone(ctx context.Context, name string) (err error) {
+	defer func(start time.Time) { l.done("DeleteSecondaryZone "+name, start, err) }(time.Now())
+	return l.next.DeleteSecondaryZone(ctx, name)
+}
+
+func (l loggingAPI) SecondaryZoneTransfer(ctx context.Context, name string) (status TransferStatus, err error) {
+	defer func(start time.Time) { l.done("SecondaryZoneTransfer "+name, start, err) }(time.Now())
+	return l.next.SecondaryZoneTransfer(ctx, name)
+}
+
+func (l loggingAPI) RetransferSecondaryZone(ctx context.Context, name string) (err error) {
+	defer func(start time.Time) { l.done("RetransferSecondaryZone "+name, start, err) }(time.Now())
+	return l.next.RetransferSecondaryZone(ctx, name)
+}
+
+func (l loggingAPI) TSIGKeys(ctx context.Context) (keys []TSIGKey, err error) {
+	defer func(start time.Time) { l.done("TSIGKeys", start, err) }(time.Now())
+	return l.next.TSIGKeys(ctx)
+}
+
+func (l loggingAPI) CreateTSIGKey(ctx context.Context, key TSIGKey) (id uint64, err error) {
+	defer func(start time.Time) { l.done("CreateTSIGKey "+key.Name, start, err) }(time.Now())
+	return l.next.CreateTSIGKey(ctx, key)
+}
+
+func (l loggingAPI) UpdateTSIGKey(ctx context.Context, name string, key TSIGKey) (err error) {
+	defer func(start time.Time) { l.done("UpdateTSIGKey "+name, start, err) }(time.Now())
+	return l.next.UpdateTSIGKey(ctx, name, key)
+}
+
+func (l loggingAPI) DeleteTSIGKey(ctx context.Context, name string) (err error) {
+	defer func(start time.Time) { l.done("DeleteTSIGKey "+name, start, err) }(time.Now())
+	return l.next.DeleteTSIGKey(ctx, name)
+}
@@ -0,0 +1,70 @@
+package dnssdk_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	"github.com/G-Core/gcore-dns-sdk-go/dnsmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadOnly(t *testing.T) {
+	api := dnsmock.NewMockAPI(gomock.NewController(t))
+	api.EXPECT().Zone(gomock.Any(), "example.com").Return(dnssdk.Zone{Name: "example.com"}, nil)
+
+	ro := dnssdk.ReadOnly(api)
+
+	zone, err := ro.Zone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+
+	err = ro.UpdateRRSet(context.Background(), "example.com", "www.example.com", "A", dnssdk.RRSet{})
+	assert.True(t, errors.Is(err, dnssdk.ErrReadOnly))
+	_, err = ro.ToggleDnssec(context.Background(), "example.com", false)
+	assert.True(t, errors.Is(err, dnssdk.ErrReadOnly))
+	err = ro.DeleteNetworkMapping(context.Background(), 1)
+	assert.EqualError(t, err, "delete network mapping 1: read-only client")
+}
+
+func TestDryRun(t *testing.T) {
+	api := dnsmock.NewMockAPI(gomock.NewController(t))
+	api.EXPECT().RRSet(gomock.Any(), "example.com", "www.example.com", "A", 0, 0).Return(dnssdk.RRSet{TTL: 60}, nil)
+
+	var logged []string
+	dry := dnssdk.DryRun(api, func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	})
+
+	rrset, err := dry.RRSet(context.Background(), "example.com", "www.example.com", "A", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 60, rrset.TTL)
+
+	require.NoError(t, dry.DeleteZone(context.Background(), "example.com"))
+	_, err = dry.CreateZone(context.Background(), dnssdk.AddZone{Name: "example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"[DRY-RUN] delete zone example.com",
+		"[DRY-RUN] create zone example.org {Contact: Enabled:false Expiry:0 Meta:map[] Name:example.org NxTTL:0 " +
+			"PrimaryServer: Refresh:0 Retry:0 Serial:0}",
+	}, logged)
+}
+
+func TestLogging(t *testing.T) {
+	api := dnsmock.NewMockAPI(gomock.NewController(t))
+	api.EXPECT().DeleteZone(gomock.Any(), "example.com").Return(errors.New("oops"))
+	api.EXPECT().EnableZone(gomock.Any(), "example.com").Return(nil)
+
+	var logged []string
+	l := dnssdk.Logging(api, func(format string, args ...interface{}) {
+		logged = append(logged, format)
+	})
+
+	assert.EqualError(t, l.DeleteZone(context.Background(), "example.com"), "oops")
+	assert.NoError(t, l.EnableZone(context.Background(), "example.com"))
+	assert.Equal(t, []string{"[DNS] %s failed in %s: %v", "[DNS] %s done in %s"}, logged)
+}
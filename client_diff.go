@@ -0,0 +1,320 @@
+package dnssdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldChange old and new value of field, values are text or compact JSON
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// RecordChange of record kept in RRSet
+type RecordChange struct {
+	Content string        `json:"content"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// RRSetChange of RRSet existing in both zones
+type RRSetChange struct {
+	Name           string         `json:"name"`
+	Type           string         `json:"type"`
+	Changes        []FieldChange  `json:"changes,omitempty"`
+	AddedRecords   []string       `json:"added_records,omitempty"`
+	RemovedRecords []string       `json:"removed_records,omitempty"`
+	ChangedRecords []RecordChange `json:"changed_records,omitempty"`
+}
+
+// ZoneDiff structural difference of two zones, names are in zone To
+type ZoneDiff struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	SOA     []FieldChange   `json:"soa,omitempty"`
+	Zone    []FieldChange   `json:"zone,omitempty"`
+	Added   []SnapshotRRSet `json:"added,omitempty"`
+	Removed []SnapshotRRSet `json:"removed,omitempty"`
+	Changed []RRSetChange   `json:"changed,omitempty"`
+}
+
+// DiffZones compares zone states a and b, e.g. snapshot with live zone taken by Client.Snapshot.
+// Zones could have different names, rrsets are matched by name relative to zone.
+func DiffZones(a, b Snapshot) ZoneDiff {
+	diff := ZoneDiff{From: a.Zone, To: b.Zone}
+
+	diff.SOA = appendChange(diff.SOA, "primary_server", a.SOA.PrimaryServer, b.SOA.PrimaryServer)
+	diff.SOA = appendChange(diff.SOA, "contact", a.SOA.Contact, b.SOA.Contact)
+	diff.SOA = appendChange(diff.SOA, "serial", fmtUint(a.SOA.Serial), fmtUint(b.SOA.Serial))
+	diff.SOA = appendChange(diff.SOA, "refresh", fmtUint(a.SOA.Refresh), fmtUint(b.SOA.Refresh))
+	diff.SOA = appendChange(diff.SOA, "retry", fmtUint(a.SOA.Retry), fmtUint(b.SOA.Retry))
+	diff.SOA = appendChange(diff.SOA, "expiry", fmtUint(a.SOA.Expiry), fmtUint(b.SOA.Expiry))
+	diff.SOA = appendChange(diff.SOA, "nx_ttl", fmtUint(a.SOA.NxTTL), fmtUint(b.SOA.NxTTL))
+	diff.Zone = appendChange(diff.Zone, "dnssec", strconv.FormatBool(a.DNSSEC), strconv.FormatBool(b.DNSSEC))
+	diff.Zone = append(diff.Zone, diffMeta("meta", a.Meta, b.Meta)...)
+
+	// names and in-zone targets of a are moved to zone b
+	renamed := make([]SnapshotRRSet, len(a.RRSets))
+	from := map[string]SnapshotRRSet{}
+	for i, set := range a.RRSets {
+		set.Name = renameHost(set.Name, a.Zone, b.Zone)
+		set.Records = renameRecords(set.Type, set.Records, a.Zone, b.Zone)
+		renamed[i] = set
+		from[set.Name+" "+set.Type] = set
+	}
+	seen := map[string]bool{}
+	for _, set := range b.RRSets {
+		key := set.Name + " " + set.Type
+		seen[key] = true
+		old, ok := from[key]
+		if !ok {
+			diff.Added = append(diff.Added, set)
+			continue
+		}
+		if change, changed := diffRRSet(old, set); changed {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	for _, set := range renamed {
+		if !seen[set.Name+" "+set.Type] {
+			diff.Removed = append(diff.Removed, set)
+		}
+	}
+
+	sortSnapshotRRSets(diff.Added)
+	sortSnapshotRRSets(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		if diff.Changed[i].Name != diff.Changed[j].Name {
+			return diff.Changed[i].Name < diff.Changed[j].Name
+		}
+		return diff.Changed[i].Type < diff.Changed[j].Type
+	})
+
+	return diff
+}
+
+// Empty is true when zones are the same
+func (d ZoneDiff) Empty() bool {
+	return len(d.SOA) == 0 && len(d.Zone) == 0 && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// WriteJSON writes indented JSON of diff
+func (d ZoneDiff) WriteJSON(w io.Writer) error {
+	bs, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode diff: %w", err)
+	}
+	_, err = w.Write(append(bs, '\n'))
+	return err
+}
+
+// WriteText writes diff in unified format with hunk per changed part
+func (d ZoneDiff) WriteText(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "--- %s\n+++ %s\n", d.From, d.To)
+	d.writeHunks(b)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMarkdown writes summary and diff block for pull requests
+func (d ZoneDiff) WriteMarkdown(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "### Zone diff `%s` → `%s`\n\n", d.From, d.To)
+	if d.Empty() {
+		b.WriteString("No changes.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	b.WriteString("| | RRSets |\n|---|---|\n")
+	fmt.Fprintf(b, "| added | %d |\n| removed | %d |\n| changed | %d |\n", len(d.Added), len(d.Removed), len(d.Changed))
+	if len(d.SOA) > 0 || len(d.Zone) > 0 {
+		fmt.Fprintf(b, "\nZone settings changed: %d.\n", len(d.SOA)+len(d.Zone))
+	}
+	b.WriteString("\n```diff\n")
+	d.writeHunks(b)
+	b.WriteString("```\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (d ZoneDiff) writeHunks(b *strings.Builder) {
+	writeFields := func(title string, changes []FieldChange) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(b, "@@ %s @@\n", title)
+		for _, c := range changes {
+			writeFieldChange(b, "", c)
+		}
+	}
+	writeFields("SOA", d.SOA)
+	writeFields("zone", d.Zone)
+
+	for _, set := range d.Removed {
+		fmt.Fprintf(b, "@@ %s %s removed @@\n", set.Name, set.Type)
+		writeRRSetLines(b, "-", set)
+	}
+	for _, set := range d.Added {
+		fmt.Fprintf(b, "@@ %s %s added @@\n", set.Name, set.Type)
+		writeRRSetLines(b, "+", set)
+	}
+	for _, change := range d.Changed {
+		fmt.Fprintf(b, "@@ %s %s @@\n", change.Name, change.Type)
+		for _, c := range change.Changes {
+			writeFieldChange(b, "", c)
+		}
+		for _, content := range change.RemovedRecords {
+			fmt.Fprintf(b, "-%s\n", content)
+		}
+		for _, content := range change.AddedRecords {
+			fmt.Fprintf(b, "+%s\n", content)
+		}
+		for _, record := range change.ChangedRecords {
+			fmt.Fprintf(b, " %s\n", record.Content)
+			for _, c := range record.Changes {
+				writeFieldChange(b, "  ", c)
+			}
+		}
+	}
+}
+
+func writeFieldChange(b *strings.Builder, indent string, c FieldChange) {
+	if c.From != "" {
+		fmt.Fprintf(b, "-%s%s %s\n", indent, c.Field, c.From)
+	}
+	if c.To != "" {
+		fmt.Fprintf(b, "+%s%s %s\n", indent, c.Field, c.To)
+	}
+}
+
+func writeRRSetLines(b *strings.Builder, sign string, set SnapshotRRSet) {
+	fmt.Fprintf(b, "%sttl %d\n", sign, set.TTL)
+	if len(set.Filters) > 0 {
+		fmt.Fprintf(b, "%sfilters %s\n", sign, compactJSON(set.Filters))
+	}
+	if len(set.Meta) > 0 {
+		fmt.Fprintf(b, "%smeta %s\n", sign, compactJSON(set.Meta))
+	}
+	for _, r := range set.Records {
+		fmt.Fprintf(b, "%s%s\n", sign, r.ContentToString())
+	}
+}
+
+func diffRRSet(a, b SnapshotRRSet) (RRSetChange, bool) {
+	change := RRSetChange{Name: b.Name, Type: b.Type}
+	change.Changes = appendChange(change.Changes, "ttl", strconv.Itoa(a.TTL), strconv.Itoa(b.TTL))
+	if !sameJSON(a.Filters, b.Filters) {
+		filters := FieldChange{Field: "filters"}
+		if len(a.Filters) > 0 {
+			filters.From = compactJSON(a.Filters)
+		}
+		if len(b.Filters) > 0 {
+			filters.To = compactJSON(b.Filters)
+		}
+		change.Changes = append(change.Changes, filters)
+	}
+	change.Changes = append(change.Changes, diffMeta("meta", a.Meta, b.Meta)...)
+
+	from := map[string]ResourceRecord{}
+	for _, r := range a.Records {
+		from[r.ContentToString()] = r
+	}
+	to := map[string]bool{}
+	for _, r := range b.Records {
+		content := r.ContentToString()
+		to[content] = true
+		old, ok := from[content]
+		if !ok {
+			change.AddedRecords = append(change.AddedRecords, content)
+			continue
+		}
+		var fields []FieldChange
+		fields = appendChange(fields, "enabled", strconv.FormatBool(old.Enabled), strconv.FormatBool(r.Enabled))
+		fields = append(fields, diffMeta("meta", old.Meta, r.Meta)...)
+		if len(fields) > 0 {
+			change.ChangedRecords = append(change.ChangedRecords, RecordChange{Content: content, Changes: fields})
+		}
+	}
+	for content := range from {
+		if !to[content] {
+			change.RemovedRecords = append(change.RemovedRecords, content)
+		}
+	}
+	sort.Strings(change.AddedRecords)
+	sort.Strings(change.RemovedRecords)
+	sort.Slice(change.ChangedRecords, func(i, j int) bool {
+		return change.ChangedRecords[i].Content < change.ChangedRecords[j].Content
+	})
+
+	changed := len(change.Changes) > 0 || len(change.AddedRecords) > 0 ||
+		len(change.RemovedRecords) > 0 || len(change.ChangedRecords) > 0
+	return change, changed
+}
+
+// diffMeta per key changes, sorted by key
+func diffMeta(prefix string, a, b map[string]any) []FieldChange {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var res []FieldChange
+	for _, k := range sorted {
+		va, okA := a[k]
+		vb, okB := b[k]
+		if okA == okB && sameJSON(va, vb) {
+			continue
+		}
+		change := FieldChange{Field: prefix + "." + k}
+		if okA {
+			change.From = compactJSON(va)
+		}
+		if okB {
+			change.To = compactJSON(vb)
+		}
+		res = append(res, change)
+	}
+	return res
+}
+
+func appendChange(changes []FieldChange, field, from, to string) []FieldChange {
+	if from == to {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, From: from, To: to})
+}
+
+func fmtUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+func compactJSON(v interface{}) string {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(bs)
+}
+
+func sortSnapshotRRSets(sets []SnapshotRRSet) {
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Name != sets[j].Name {
+			return sets[i].Name < sets[j].Name
+		}
+		return sets[i].Type < sets[j].Type
+	})
+}
@@ -0,0 +1,132 @@
+package dnssdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diffFixture() (Snapshot, Snapshot) {
+	a := Snapshot{
+		Zone: "example.com",
+		SOA:  SnapshotSOA{Serial: 1, Refresh: 3600},
+		RRSets: []SnapshotRRSet{
+			{Name: "www.example.com", Type: "A", TTL: 60, Records: []ResourceRecord{
+				{Content: []any{"192.0.2.1"}, Enabled: true},
+				{Content: []any{"192.0.2.2"}, Enabled: true, Meta: map[string]any{"weight": 1}},
+			}},
+			{Name: "old.example.com", Type: "TXT", TTL: 60, Records: []ResourceRecord{{Content: []any{"bye"}, Enabled: true}}},
+			{Name: "example.com", Type: "MX", TTL: 3600, Records: []ResourceRecord{{Content: []any{10, "mx.example.com."}, Enabled: true}}},
+			{Name: "cdn.example.com", Type: "CNAME", TTL: 60, Records: []ResourceRecord{{Content: []any{"cdn.example.net."}, Enabled: true}}},
+		},
+	}
+	b := Snapshot{
+		Zone:   "example.org",
+		SOA:    SnapshotSOA{Serial: 2, Refresh: 7200},
+		DNSSEC: true,
+		RRSets: []SnapshotRRSet{
+			{Name: "www.example.org", Type: "A", TTL: 300, Filters: []RecordFilter{NewGeoDNSFilter(1, true)},
+				Meta: RRSetMeta{"geodns_link": "office"},
+				Records: []ResourceRecord{
+					{Content: []any{"192.0.2.2"}, Enabled: false, Meta: map[string]any{"weight": 2}},
+					{Content: []any{"192.0.2.3"}, Enabled: true},
+				}},
+			{Name: "new.example.org", Type: "TXT", TTL: 60, Records: []ResourceRecord{{Content: []any{"hi"}, Enabled: true}}},
+			{Name: "example.org", Type: "MX", TTL: 3600, Records: []ResourceRecord{{Content: []any{10, "mx.example.org."}, Enabled: true}}},
+			{Name: "cdn.example.org", Type: "CNAME", TTL: 60, Records: []ResourceRecord{{Content: []any{"cdn.example.net."}, Enabled: true}}},
+		},
+	}
+	return a, b
+}
+
+func TestDiffZones(t *testing.T) {
+	a, b := diffFixture()
+	diff := DiffZones(a, b)
+
+	assert.False(t, diff.Empty())
+	assert.Equal(t, []FieldChange{
+		{Field: "serial", From: "1", To: "2"},
+		{Field: "refresh", From: "3600", To: "7200"},
+	}, diff.SOA)
+	assert.Equal(t, []FieldChange{{Field: "dnssec", From: "false", To: "true"}}, diff.Zone)
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "new.example.org", diff.Added[0].Name)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "old.example.org", diff.Removed[0].Name, "names are moved to zone b")
+
+	require.Len(t, diff.Changed, 1, "MX target is moved to zone b, CNAME target out of zone is kept")
+	assert.Equal(t, RRSetChange{
+		Name: "www.example.org",
+		Type: "A",
+		Changes: []FieldChange{
+			{Field: "ttl", From: "60", To: "300"},
+			{Field: "filters", To: `[{"limit":1,"type":"geodns","strict":true}]`},
+			{Field: "meta.geodns_link", To: `"office"`},
+		},
+		AddedRecords:   []string{"192.0.2.3"},
+		RemovedRecords: []string{"192.0.2.1"},
+		ChangedRecords: []RecordChange{{Content: "192.0.2.2", Changes: []FieldChange{
+			{Field: "enabled", From: "true", To: "false"},
+			{Field: "meta.weight", From: "1", To: "2"},
+		}}},
+	}, diff.Changed[0])
+
+	assert.True(t, DiffZones(a, a).Empty())
+}
+
+func TestZoneDiff_output(t *testing.T) {
+	a, b := diffFixture()
+	diff := DiffZones(a, b)
+
+	buf := bytes.Buffer{}
+	require.NoError(t, diff.WriteText(&buf))
+	assert.Equal(t, `--- example.com
++++ example.org
+@@ SOA @@
+-serial 1
++serial 2
+-refresh 3600
++refresh 7200
+@@ zone @@
+-dnssec false
++dnssec true
+@@ old.example.org TXT removed @@
+-ttl 60
+-bye
+@@ new.example.org TXT added @@
++ttl 60
++hi
+@@ www.example.org A @@
+-ttl 60
++ttl 300
++filters [{"limit":1,"type":"geodns","strict":true}]
++meta.geodns_link "office"
+-192.0.2.1
++192.0.2.3
+ 192.0.2.2
+-  enabled true
++  enabled false
+-  meta.weight 1
++  meta.weight 2
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, diff.WriteMarkdown(&buf))
+	assert.Contains(t, buf.String(), "### Zone diff `example.com` → `example.org`")
+	assert.Contains(t, buf.String(), "| changed | 1 |")
+	assert.Contains(t, buf.String(), "```diff\n@@ SOA @@\n")
+
+	buf.Reset()
+	require.NoError(t, diff.WriteJSON(&buf))
+	var decoded ZoneDiff
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, diff.Changed, decoded.Changed)
+
+	buf.Reset()
+	require.NoError(t, DiffZones(a, a).WriteMarkdown(&buf))
+	assert.Contains(t, buf.String(), "No changes.")
+}
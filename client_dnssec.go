@@ -0,0 +1,426 @@
+package dnssdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// ErrDSMismatch returned when DS digest does not match DNSKEY
+var ErrDSMismatch = errors.New("ds does not match dnskey")
+
+// DNSSecAlgorithm IANA DNSSEC algorithm number
+type DNSSecAlgorithm uint8
+
+// DNSSEC algorithms
+const (
+	AlgorithmRSAMD5           DNSSecAlgorithm = 1
+	AlgorithmDSA              DNSSecAlgorithm = 3
+	AlgorithmRSASHA1          DNSSecAlgorithm = 5
+	AlgorithmDSANSEC3SHA1     DNSSecAlgorithm = 6
+	AlgorithmRSASHA1NSEC3SHA1 DNSSecAlgorithm = 7
+	AlgorithmRSASHA256        DNSSecAlgorithm = 8
+	AlgorithmRSASHA512        DNSSecAlgorithm = 10
+	AlgorithmECCGOST          DNSSecAlgorithm = 12
+	AlgorithmECDSAP256SHA256  DNSSecAlgorithm = 13
+	AlgorithmECDSAP384SHA384  DNSSecAlgorithm = 14
+	AlgorithmED25519          DNSSecAlgorithm = 15
+	AlgorithmED448            DNSSecAlgorithm = 16
+)
+
+var dnssecAlgorithmNames = map[DNSSecAlgorithm]string{
+	AlgorithmRSAMD5:           "RSAMD5",
+	AlgorithmDSA:              "DSA",
+	AlgorithmRSASHA1:          "RSASHA1",
+	AlgorithmDSANSEC3SHA1:     "DSA-NSEC3-SHA1",
+	AlgorithmRSASHA1NSEC3SHA1: "RSASHA1-NSEC3-SHA1",
+	AlgorithmRSASHA256:        "RSASHA256",
+	AlgorithmRSASHA512:        "RSASHA512",
+	AlgorithmECCGOST:          "ECC-GOST",
+	AlgorithmECDSAP256SHA256:  "ECDSAP256SHA256",
+	AlgorithmECDSAP384SHA384:  "ECDSAP384SHA384",
+	AlgorithmED25519:          "ED25519",
+	AlgorithmED448:            "ED448",
+}
+
+// String IANA mnemonic
+func (a DNSSecAlgorithm) String() string {
+	if name, ok := dnssecAlgorithmNames[a]; ok {
+		return name
+	}
+	return strconv.Itoa(int(a))
+}
+
+// ParseDNSSecAlgorithm from number or mnemonic, e.g. 13 or ECDSAP256SHA256
+func ParseDNSSecAlgorithm(s string) (DNSSecAlgorithm, error) {
+	if n, err := strconv.ParseUint(strings.TrimSpace(s), 10, 8); err == nil {
+		return DNSSecAlgorithm(n), nil
+	}
+	for alg, name := range dnssecAlgorithmNames {
+		if alnum(name) == alnum(s) {
+			return alg, nil
+		}
+	}
+	// nolint: goerr113
+	return 0, fmt.Errorf("unknown dnssec algorithm %q", s)
+}
+
+// DNSSecDigestType IANA DS digest type number
+type DNSSecDigestType uint8
+
+// DS digest types
+const (
+	DigestSHA1   DNSSecDigestType = 1
+	DigestSHA256 DNSSecDigestType = 2
+	DigestGOST   DNSSecDigestType = 3
+	DigestSHA384 DNSSecDigestType = 4
+)
+
+var dnssecDigestNames = map[DNSSecDigestType]string{
+	DigestSHA1:   "SHA-1",
+	DigestSHA256: "SHA-256",
+	DigestGOST:   "GOST R 34.11-94",
+	DigestSHA384: "SHA-384",
+}
+
+// String IANA mnemonic
+func (d DNSSecDigestType) String() string {
+	if name, ok := dnssecDigestNames[d]; ok {
+		return name
+	}
+	return strconv.Itoa(int(d))
+}
+
+// ParseDNSSecDigestType from number or mnemonic, e.g. 2, SHA256 or SHA-256
+func ParseDNSSecDigestType(s string) (DNSSecDigestType, error) {
+	if n, err := strconv.ParseUint(strings.TrimSpace(s), 10, 8); err == nil {
+		return DNSSecDigestType(n), nil
+	}
+	for digest, name := range dnssecDigestNames {
+		if alnum(name) == alnum(s) || (digest == DigestGOST && alnum(s) == "GOST") {
+			return digest, nil
+		}
+	}
+	// nolint: goerr113
+	return 0, fmt.Errorf("unknown ds digest type %q", s)
+}
+
+func alnum(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// DSRecord typed DS of zone
+type DSRecord struct {
+	Zone       string           `json:"zone"`
+	TTL        uint32           `json:"ttl,omitempty"`
+	KeyTag     uint16           `json:"key_tag"`
+	Algorithm  DNSSecAlgorithm  `json:"algorithm"`
+	DigestType DNSSecDigestType `json:"digest_type"`
+	// Digest upper case hex
+	Digest string `json:"digest"`
+}
+
+// Text registrar form: key tag, algorithm, digest type and digest
+func (ds DSRecord) Text() string {
+	return fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest)
+}
+
+// String zone file form
+func (ds DSRecord) String() string {
+	return ds.RR().String()
+}
+
+// RR convertor to miekg/dns record
+func (ds DSRecord) RR() *dns.DS {
+	return &dns.DS{
+		Hdr:    dns.RR_Header{Name: dns.Fqdn(ds.Zone), Rrtype: dns.TypeDS, Class: dns.ClassINET, Ttl: ds.TTL},
+		KeyTag: ds.KeyTag, Algorithm: uint8(ds.Algorithm), DigestType: uint8(ds.DigestType),
+		Digest: strings.ToUpper(ds.Digest),
+	}
+}
+
+// Matches checks that digest of ds is calculated from key
+func (ds DSRecord) Matches(key DNSKeyRecord) error {
+	expected, err := key.DS(ds.DigestType)
+	if err != nil {
+		return err
+	}
+	switch {
+	case expected.KeyTag != ds.KeyTag:
+		return fmt.Errorf("%w: key tag %d, key has %d", ErrDSMismatch, ds.KeyTag, expected.KeyTag)
+	case expected.Algorithm != ds.Algorithm:
+		return fmt.Errorf("%w: algorithm %s, key has %s", ErrDSMismatch, ds.Algorithm, expected.Algorithm)
+	case !strings.EqualFold(expected.Digest, ds.Digest):
+		return fmt.Errorf("%w: digest %s, key has %s", ErrDSMismatch, ds.Digest, expected.Digest)
+	case normalizeName(expected.Zone) != normalizeName(ds.Zone):
+		return fmt.Errorf("%w: zone %s, key of %s", ErrDSMismatch, ds.Zone, expected.Zone)
+	}
+	return nil
+}
+
+// DNSKeyRecord typed DNSKEY of zone
+type DNSKeyRecord struct {
+	Zone      string          `json:"zone"`
+	TTL       uint32          `json:"ttl,omitempty"`
+	Flags     uint16          `json:"flags"`
+	Protocol  uint8           `json:"protocol"`
+	Algorithm DNSSecAlgorithm `json:"algorithm"`
+	// PublicKey base64 of key
+	PublicKey string `json:"public_key"`
+}
+
+// KSK is true for key signing key, secure entry point flag is set
+func (k DNSKeyRecord) KSK() bool {
+	return k.Flags&dns.SEP != 0
+}
+
+// KeyTag of key
+func (k DNSKeyRecord) KeyTag() uint16 {
+	return k.RR().KeyTag()
+}
+
+// Text registrar form: flags, protocol, algorithm and public key
+func (k DNSKeyRecord) Text() string {
+	return fmt.Sprintf("%d %d %d %s", k.Flags, k.Protocol, k.Algorithm, k.PublicKey)
+}
+
+// String zone file form
+func (k DNSKeyRecord) String() string {
+	return k.RR().String()
+}
+
+// RR convertor to miekg/dns record
+func (k DNSKeyRecord) RR() *dns.DNSKEY {
+	return &dns.DNSKEY{
+		Hdr:   dns.RR_Header{Name: dns.Fqdn(k.Zone), Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: k.TTL},
+		Flags: k.Flags, Protocol: k.Protocol, Algorithm: uint8(k.Algorithm), PublicKey: k.PublicKey,
+	}
+}
+
+// DS of key with digest type
+func (k DNSKeyRecord) DS(digestType DNSSecDigestType) (DSRecord, error) {
+	ds := k.RR().ToDS(uint8(digestType))
+	if ds == nil {
+		// nolint: goerr113
+		return DSRecord{}, fmt.Errorf("can not calculate %s digest of key %d", digestType, k.KeyTag())
+	}
+	return DSRecord{Zone: k.Zone, TTL: k.TTL, KeyTag: ds.KeyTag, Algorithm: k.Algorithm,
+		DigestType: digestType, Digest: strings.ToUpper(ds.Digest)}, nil
+}
+
+// TypedDS of zone from API response, structured fields are preferred over ds text
+func (d DNSSecDS) TypedDS(zone string) (DSRecord, error) {
+	ds := DSRecord{Zone: strings.TrimSuffix(zone, ".")}
+	if d.Ds != "" {
+		parsed, err := parseDSText(d.Ds)
+		if err != nil {
+			return DSRecord{}, err
+		}
+		ds = parsed
+		if zone != "" {
+			ds.Zone = strings.TrimSuffix(zone, ".")
+		}
+	}
+	if d.KeyTag != 0 {
+		ds.KeyTag = uint16(d.KeyTag)
+	}
+	if d.Algorithm != "" {
+		alg, err := ParseDNSSecAlgorithm(d.Algorithm)
+		if err != nil {
+			return DSRecord{}, err
+		}
+		ds.Algorithm = alg
+	}
+	digestType := d.DigestType
+	if digestType == "" {
+		digestType = d.DigestAlgorithm
+	}
+	if digestType != "" {
+		digest, err := ParseDNSSecDigestType(digestType)
+		if err != nil {
+			return DSRecord{}, err
+		}
+		ds.DigestType = digest
+	}
+	if d.Digest != "" {
+		ds.Digest = strings.ToUpper(strings.Join(strings.Fields(d.Digest), ""))
+	}
+	if ds.Digest == "" || ds.Algorithm == 0 || ds.DigestType == 0 {
+		// nolint: goerr113
+		return DSRecord{}, fmt.Errorf("ds of %s is not published yet", zone)
+	}
+	return ds, nil
+}
+
+// parseDSText of "[name [ttl] [IN] DS] key_tag algorithm digest_type digest"
+func parseDSText(text string) (DSRecord, error) {
+	fields := strings.Fields(text)
+	ds := DSRecord{}
+	for i, f := range fields {
+		if strings.EqualFold(f, "DS") {
+			if i > 0 {
+				ds.Zone = strings.TrimSuffix(fields[0], ".")
+			}
+			// nolint: gomnd
+			if i > 1 {
+				if ttl, err := strconv.ParseUint(fields[1], 10, 32); err == nil {
+					ds.TTL = uint32(ttl)
+				}
+			}
+			fields = fields[i+1:]
+			break
+		}
+	}
+	// nolint: gomnd
+	if len(fields) < 4 {
+		// nolint: goerr113
+		return DSRecord{}, fmt.Errorf("bad ds %q", text)
+	}
+	keyTag, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return DSRecord{}, fmt.Errorf("ds key tag: %w", err)
+	}
+	ds.KeyTag = uint16(keyTag)
+	if ds.Algorithm, err = ParseDNSSecAlgorithm(fields[1]); err != nil {
+		return DSRecord{}, err
+	}
+	if ds.DigestType, err = ParseDNSSecDigestType(fields[2]); err != nil {
+		return DSRecord{}, err
+	}
+	ds.Digest = strings.ToUpper(strings.Join(fields[3:], ""))
+	return ds, nil
+}
+
+// TypedDNSKey of zone from API response, 257 flags are used when API returns none
+func (d DNSSecDS) TypedDNSKey(zone string) (DNSKeyRecord, error) {
+	if d.PublicKey == "" {
+		// nolint: goerr113
+		return DNSKeyRecord{}, fmt.Errorf("public key of %s is not published yet", zone)
+	}
+	key := DNSKeyRecord{Zone: strings.TrimSuffix(zone, "."), Flags: uint16(d.Flags), Protocol: 3,
+		PublicKey: strings.Join(strings.Fields(d.PublicKey), "")}
+	if key.Flags == 0 {
+		key.Flags = dns.ZONE | dns.SEP
+	}
+	alg, err := ParseDNSSecAlgorithm(d.Algorithm)
+	if err != nil {
+		return DNSKeyRecord{}, err
+	}
+	key.Algorithm = alg
+	return key, nil
+}
+
+// Verify checks that DS of API response matches its public key
+func (d DNSSecDS) Verify(zone string) error {
+	ds, err := d.TypedDS(zone)
+	if err != nil {
+		return err
+	}
+	key, err := d.TypedDNSKey(zone)
+	if err != nil {
+		return err
+	}
+	return ds.Matches(key)
+}
+
+// DSExportFormat of Export
+type DSExportFormat string
+
+// export formats for registrars
+const (
+	// ExportDSText key tag, algorithm, digest type and digest
+	ExportDSText DSExportFormat = "ds"
+	// ExportDNSKeyText flags, protocol, algorithm and public key, for registries calculating DS themselves
+	ExportDNSKeyText DSExportFormat = "dnskey"
+	// ExportZoneFile DS and DNSKEY in zone file form
+	ExportZoneFile DSExportFormat = "zonefile"
+	// ExportJSON RegistrarDNSSec as JSON, for registrar APIs
+	ExportJSON DSExportFormat = "json"
+)
+
+// RegistrarDNSSec DS and DNSKEY with mnemonics for registrar APIs
+type RegistrarDNSSec struct {
+	Zone   string               `json:"zone"`
+	DS     RegistrarDSData      `json:"ds"`
+	DNSKey *RegistrarDNSKeyData `json:"dnskey,omitempty"`
+}
+
+// RegistrarDSData dto of DS for registrar APIs
+type RegistrarDSData struct {
+	KeyTag         uint16 `json:"key_tag"`
+	Algorithm      uint8  `json:"algorithm"`
+	AlgorithmName  string `json:"algorithm_name"`
+	DigestType     uint8  `json:"digest_type"`
+	DigestTypeName string `json:"digest_type_name"`
+	Digest         string `json:"digest"`
+}
+
+// RegistrarDNSKeyData dto of DNSKEY for registrar APIs
+type RegistrarDNSKeyData struct {
+	Flags         uint16 `json:"flags"`
+	Protocol      uint8  `json:"protocol"`
+	Algorithm     uint8  `json:"algorithm"`
+	AlgorithmName string `json:"algorithm_name"`
+	PublicKey     string `json:"public_key"`
+}
+
+// Export DS of API response for registrar, DS is checked against public key when it is present
+func (d DNSSecDS) Export(zone string, format DSExportFormat) (string, error) {
+	ds, err := d.TypedDS(zone)
+	if err != nil {
+		return "", err
+	}
+	var key *DNSKeyRecord
+	if d.PublicKey != "" {
+		k, err := d.TypedDNSKey(zone)
+		if err != nil {
+			return "", err
+		}
+		if err = ds.Matches(k); err != nil {
+			return "", err
+		}
+		key = &k
+	}
+
+	switch format {
+	case ExportDSText:
+		return ds.Text(), nil
+	case ExportDNSKeyText, ExportZoneFile:
+		if key == nil {
+			// nolint: goerr113
+			return "", fmt.Errorf("public key of %s is not published yet", zone)
+		}
+		if format == ExportDNSKeyText {
+			return key.Text(), nil
+		}
+		return ds.String() + "\n" + key.String() + "\n", nil
+	case ExportJSON:
+		out := RegistrarDNSSec{Zone: ds.Zone, DS: RegistrarDSData{
+			KeyTag: ds.KeyTag, Algorithm: uint8(ds.Algorithm), AlgorithmName: ds.Algorithm.String(),
+			DigestType: uint8(ds.DigestType), DigestTypeName: ds.DigestType.String(), Digest: ds.Digest,
+		}}
+		if key != nil {
+			out.DNSKey = &RegistrarDNSKeyData{Flags: key.Flags, Protocol: key.Protocol,
+				Algorithm: uint8(key.Algorithm), AlgorithmName: key.Algorithm.String(), PublicKey: key.PublicKey}
+		}
+		bs, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("encode ds: %w", err)
+		}
+		return string(bs), nil
+	}
+	// nolint: goerr113
+	return "", fmt.Errorf("unknown export format %q", format)
+}
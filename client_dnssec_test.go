@@ -0,0 +1,160 @@
+package dnssdk
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dnssecFixture(t *testing.T) (DNSSecDS, *dns.DNSKEY) {
+	t.Helper()
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	_, err := key.Generate(256)
+	require.NoError(t, err)
+	ds := key.ToDS(dns.SHA256)
+
+	return DNSSecDS{
+		Algorithm:       "13",
+		Digest:          ds.Digest,
+		DigestAlgorithm: "SHA256",
+		DigestType:      "2",
+		Ds:              "example.com. 3600 IN DS " + strconv.Itoa(int(ds.KeyTag)) + " 13 2 " + ds.Digest,
+		Flags:           257,
+		KeyTag:          int(ds.KeyTag),
+		KeyType:         "KSK",
+		PublicKey:       key.PublicKey,
+	}, key
+}
+
+func TestParseDNSSecAlgorithm(t *testing.T) {
+	for in, want := range map[string]DNSSecAlgorithm{
+		"13": AlgorithmECDSAP256SHA256, "ecdsap256sha256": AlgorithmECDSAP256SHA256,
+		"RSASHA256": AlgorithmRSASHA256, "ED25519": AlgorithmED25519, "rsasha1-nsec3-sha1": AlgorithmRSASHA1NSEC3SHA1,
+	} {
+		got, err := ParseDNSSecAlgorithm(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseDNSSecAlgorithm("ROT13")
+	assert.Error(t, err)
+	assert.Equal(t, "ECDSAP256SHA256", AlgorithmECDSAP256SHA256.String())
+	assert.Equal(t, "200", DNSSecAlgorithm(200).String())
+
+	for in, want := range map[string]DNSSecDigestType{"2": DigestSHA256, "SHA256": DigestSHA256, "sha-384": DigestSHA384,
+		"SHA1": DigestSHA1, "GOST": DigestGOST} {
+		got, err := ParseDNSSecDigestType(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err = ParseDNSSecDigestType("MD5")
+	assert.Error(t, err)
+}
+
+func TestDNSSecDS_Typed(t *testing.T) {
+	api, key := dnssecFixture(t)
+
+	ds, err := api.TypedDS("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, DSRecord{Zone: "example.com", TTL: 3600, KeyTag: key.KeyTag(), Algorithm: AlgorithmECDSAP256SHA256,
+		DigestType: DigestSHA256, Digest: strings.ToUpper(api.Digest)}, ds)
+
+	dnskey, err := api.TypedDNSKey("example.com.")
+	require.NoError(t, err)
+	assert.True(t, dnskey.KSK())
+	assert.Equal(t, key.KeyTag(), dnskey.KeyTag())
+	require.NoError(t, ds.Matches(dnskey))
+	require.NoError(t, api.Verify("example.com"))
+
+	// only text of DS
+	ds, err = DNSSecDS{Ds: "12345 ECDSAP256SHA256 SHA-256 ab cd"}.TypedDS("example.org")
+	require.NoError(t, err)
+	assert.Equal(t, DSRecord{Zone: "example.org", KeyTag: 12345, Algorithm: AlgorithmECDSAP256SHA256,
+		DigestType: DigestSHA256, Digest: "ABCD"}, ds)
+
+	_, err = DNSSecDS{}.TypedDS("example.com")
+	assert.Error(t, err)
+	_, err = DNSSecDS{Ds: "example.com. DS 1 13"}.TypedDS("example.com")
+	assert.Error(t, err)
+}
+
+func TestDSRecord_Matches(t *testing.T) {
+	api, _ := dnssecFixture(t)
+	other, _ := dnssecFixture(t)
+
+	api.Digest, api.Ds = other.Digest, ""
+	assert.ErrorIs(t, api.Verify("example.com"), ErrDSMismatch)
+
+	api, _ = dnssecFixture(t)
+	ds, err := api.TypedDS("example.com")
+	require.NoError(t, err)
+	key, err := api.TypedDNSKey("example.net")
+	require.NoError(t, err)
+	assert.ErrorIs(t, ds.Matches(key), ErrDSMismatch, "owner name is part of digest")
+
+	key, err = api.TypedDNSKey("example.com")
+	require.NoError(t, err)
+	sha384, err := key.DS(DigestSHA384)
+	require.NoError(t, err)
+	assert.Len(t, sha384.Digest, 96)
+	require.NoError(t, sha384.Matches(key))
+}
+
+func TestDNSSecDS_Export(t *testing.T) {
+	api, key := dnssecFixture(t)
+	tag := strconv.Itoa(int(key.KeyTag()))
+
+	text, err := api.Export("example.com", ExportDSText)
+	require.NoError(t, err)
+	assert.Equal(t, tag+" 13 2 "+strings.ToUpper(api.Digest), text)
+
+	text, err = api.Export("example.com", ExportDNSKeyText)
+	require.NoError(t, err)
+	assert.Equal(t, "257 3 13 "+key.PublicKey, text)
+
+	text, err = api.Export("example.com", ExportZoneFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	require.Len(t, lines, 2)
+	rr, err := dns.NewRR(lines[0])
+	require.NoError(t, err)
+	assert.Equal(t, key.KeyTag(), rr.(*dns.DS).KeyTag)
+	rr, err = dns.NewRR(lines[1])
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey, rr.(*dns.DNSKEY).PublicKey)
+
+	text, err = api.Export("example.com", ExportJSON)
+	require.NoError(t, err)
+	var decoded RegistrarDNSSec
+	require.NoError(t, json.Unmarshal([]byte(text), &decoded))
+	assert.Equal(t, "example.com", decoded.Zone)
+	assert.Equal(t, "ECDSAP256SHA256", decoded.DS.AlgorithmName)
+	assert.Equal(t, "SHA-256", decoded.DS.DigestTypeName)
+	require.NotNil(t, decoded.DNSKey)
+	assert.Equal(t, uint16(257), decoded.DNSKey.Flags)
+
+	_, err = api.Export("example.com", "xml")
+	assert.Error(t, err)
+
+	api.PublicKey = ""
+	_, err = api.Export("example.com", ExportDNSKeyText)
+	assert.Error(t, err)
+	text, err = api.Export("example.com", ExportDSText)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(text, tag))
+
+	broken, _ := dnssecFixture(t)
+	broken.KeyTag++
+	broken.Ds = ""
+	_, err = broken.Export("example.com", ExportDSText)
+	assert.ErrorIs(t, err, ErrDSMismatch)
+}
@@ -0,0 +1,456 @@
+package dnssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	defaultDNSSecPollInterval   = 10 * time.Second
+	defaultDNSSecSigningTimeout = 10 * time.Minute
+	defaultDSTTL                = 24 * time.Hour
+	dnssecUDPSize               = 4096
+)
+
+// errors of DNSSEC workflows
+var (
+	// ErrDNSSecChain wrapped by errors of chain of trust checks
+	ErrDNSSecChain = errors.New("dnssec chain of trust")
+	// ErrParentDSPresent returned by DisableDNSSec while parent publishes DS of zone
+	ErrParentDSPresent = errors.New("parent publishes ds")
+)
+
+// DNSSecResolver sends DNS queries of DNSSEC workflows, ServerResolver or custom one for tests
+type DNSSecResolver interface {
+	Exchange(ctx context.Context, m *dns.Msg) (*dns.Msg, error)
+}
+
+// ServerResolver sends queries to DNS server, e.g. validating recursive resolver
+type ServerResolver struct {
+	// Addr host or host:port, 53 port is used when omitted
+	Addr   string
+	Client *dns.Client
+}
+
+// NewServerResolver with default client
+func NewServerResolver(addr string) *ServerResolver {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, fmt.Sprint(defaultDNSPort))
+	}
+	return &ServerResolver{Addr: addr, Client: &dns.Client{}}
+}
+
+// Exchange implementation, truncated answers are repeated over tcp
+func (r *ServerResolver) Exchange(ctx context.Context, m *dns.Msg) (*dns.Msg, error) {
+	client := r.Client
+	if client == nil {
+		client = &dns.Client{}
+	}
+	resp, _, err := client.ExchangeContext(ctx, m, r.Addr)
+	if err == nil && resp.Truncated && client.Net == "" {
+		tcp := *client
+		tcp.Net = "tcp"
+		resp, _, err = tcp.ExchangeContext(ctx, m, r.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", r.Addr, err)
+	}
+	return resp, nil
+}
+
+// DNSSecStage of DNSSEC workflow
+type DNSSecStage string
+
+// stages of DNSSEC workflows
+const (
+	DNSSecStageEnable   DNSSecStage = "enable"
+	DNSSecStageSigned   DNSSecStage = "signed"
+	DNSSecStageParentDS DNSSecStage = "parent_ds"
+	DNSSecStageValidate DNSSecStage = "validate"
+	DNSSecStageDSTTL    DNSSecStage = "ds_ttl"
+	DNSSecStageDisable  DNSSecStage = "disable"
+)
+
+// DNSSecStageReport result of workflow stage, Err is nil for passed stage
+type DNSSecStageReport struct {
+	Stage   DNSSecStage
+	Message string
+	Err     error
+}
+
+// DNSSecReport of DNSSEC workflow
+type DNSSecReport struct {
+	Zone   string
+	DS     DNSSecDS
+	Stages []DNSSecStageReport
+	// Secure is true when parent DS matches zone keys and signatures validate
+	Secure bool
+}
+
+func (r *DNSSecReport) stage(onStage func(DNSSecStageReport), stage DNSSecStage, err error, format string, args ...any) error {
+	report := DNSSecStageReport{Stage: stage, Message: fmt.Sprintf(format, args...), Err: err}
+	r.Stages = append(r.Stages, report)
+	if onStage != nil {
+		onStage(report)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", stage, err)
+	}
+	return nil
+}
+
+// EnableDNSSecOptions of EnableDNSSec
+type EnableDNSSecOptions struct {
+	// Resolver checks parent DS and signatures, workflow stops when DS is published by API without it
+	Resolver DNSSecResolver
+	// PollInterval of DS and parent checks, 10s when empty
+	PollInterval time.Duration
+	// SigningTimeout of waiting for DS, 10m when empty
+	SigningTimeout time.Duration
+	// ParentTimeout of waiting for DS at parent, it is checked once when empty
+	ParentTimeout time.Duration
+	// OnStage is called after every stage
+	OnStage func(DNSSecStageReport)
+}
+
+// EnableDNSSec enables DNSSEC of zone and waits for DS, then checks with resolver
+// that parent publishes matching DS and zone keys and signatures validate.
+// Report contains every passed stage and the failed one.
+func (c *Client) EnableDNSSec(ctx context.Context, zone string, opts EnableDNSSecOptions) (DNSSecReport, error) {
+	zone = strings.Trim(zone, ".")
+	report := DNSSecReport{Zone: zone}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultDNSSecPollInterval
+	}
+	signingTimeout := opts.SigningTimeout
+	if signingTimeout <= 0 {
+		signingTimeout = defaultDNSSecSigningTimeout
+	}
+
+	ds, err := c.ToggleDnssec(ctx, zone, true)
+	if err != nil {
+		return report, report.stage(opts.OnStage, DNSSecStageEnable, err, "enable dnssec")
+	}
+	_ = report.stage(opts.OnStage, DNSSecStageEnable, nil, "dnssec is enabled")
+
+	var expected DSRecord
+	err = pollDNSSec(ctx, interval, signingTimeout, func() (bool, error) {
+		var errTyped error
+		if expected, errTyped = ds.TypedDS(zone); errTyped == nil {
+			if ds.PublicKey == "" {
+				return true, nil
+			}
+			return true, ds.Verify(zone)
+		}
+		var errDS error
+		ds, errDS = c.DNSSecDS(ctx, zone)
+		if errDS != nil && !retryable(errDS) {
+			return false, errDS
+		}
+		return false, nil
+	})
+	report.DS = ds
+	if err != nil {
+		return report, report.stage(opts.OnStage, DNSSecStageSigned, err, "zone is not signed")
+	}
+	_ = report.stage(opts.OnStage, DNSSecStageSigned, nil, "zone is signed, publish DS at registrar: %s", expected.Text())
+
+	if opts.Resolver == nil {
+		return report, nil
+	}
+
+	err = pollDNSSec(ctx, interval, opts.ParentTimeout, func() (bool, error) {
+		parent, errLookup := LookupParentDS(ctx, opts.Resolver, zone)
+		if errLookup != nil {
+			return false, errLookup
+		}
+		for _, p := range parent {
+			if sameDS(p, expected) {
+				return true, nil
+			}
+		}
+		if len(parent) == 0 {
+			return false, fmt.Errorf("%w: parent has no DS of %s", ErrDNSSecChain, zone)
+		}
+		return false, fmt.Errorf("%w: parent DS of %s does not match %s", ErrDNSSecChain, zone, expected.Text())
+	})
+	if err != nil {
+		return report, report.stage(opts.OnStage, DNSSecStageParentDS, err,
+			"publish DS at registrar: %s", expected.Text())
+	}
+	_ = report.stage(opts.OnStage, DNSSecStageParentDS, nil, "parent publishes DS %s", expected.Text())
+
+	if err = ValidateDNSSecChain(ctx, opts.Resolver, zone, []DSRecord{expected}); err != nil {
+		return report, report.stage(opts.OnStage, DNSSecStageValidate, err, "zone does not validate")
+	}
+	report.Secure = true
+	_ = report.stage(opts.OnStage, DNSSecStageValidate, nil, "DNSKEY and signatures validate")
+	return report, nil
+}
+
+// pollDNSSec calls check until it is done, last error is returned after timeout, zero timeout is single check
+func pollDNSSec(ctx context.Context, interval, timeout time.Duration, check func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		done, err := check()
+		if done || (err != nil && !errors.Is(err, ErrDNSSecChain) && !errors.Is(err, ErrParentDSPresent) &&
+			!isTemporaryDNS(err)) {
+			return err
+		}
+		if !time.Now().Add(interval).Before(deadline) {
+			if err == nil {
+				// nolint: goerr113
+				err = fmt.Errorf("timeout after %s", timeout)
+			}
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// DisableDNSSecOptions of DisableDNSSec
+type DisableDNSSecOptions struct {
+	// Resolver checks parent DS, required unless Force is set
+	Resolver DNSSecResolver
+	// Force disables DNSSEC without checks, zone goes bogus while parent publishes DS
+	Force bool
+	// PollInterval of parent checks, 10s when empty
+	PollInterval time.Duration
+	// ParentTimeout of waiting for DS removal at parent, it is checked once when empty
+	ParentTimeout time.Duration
+	// DSTTL min wait after parent stops publishing DS, 24h when empty.
+	// Original TTL of DS seen at parent is waited when it is longer.
+	// The wait happens even when parent never published DS, e.g. DNSSEC was enabled but DS was not added
+	// at registrar, set short DSTTL then to disable without blocking for 24h.
+	DSTTL time.Duration
+	// OnStage is called after every stage
+	OnStage func(DNSSecStageReport)
+}
+
+// DisableDNSSec disables DNSSEC of zone only when parent does not publish DS.
+// DS must be removed at registrar first, then resolvers keep cached DS up to its TTL,
+// so DisableDNSSec waits for DSTTL or original TTL of DS seen at parent before disabling.
+// Original TTL is taken from RRSIG of DS, TTL of answer is decremented by recursive resolver.
+// Without DSTTL it blocks for 24h even when parent has never published DS.
+func (c *Client) DisableDNSSec(ctx context.Context, zone string, opts DisableDNSSecOptions) (DNSSecReport, error) {
+	zone = strings.Trim(zone, ".")
+	report := DNSSecReport{Zone: zone}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultDNSSecPollInterval
+	}
+
+	if !opts.Force {
+		if opts.Resolver == nil {
+			// nolint: goerr113
+			return report, errors.New("disable dnssec: resolver is required unless forced")
+		}
+		ttl := opts.DSTTL
+		if ttl <= 0 {
+			ttl = defaultDSTTL
+		}
+		err := pollDNSSec(ctx, interval, opts.ParentTimeout, func() (bool, error) {
+			rrs, sigs, errLookup := lookupDNSSec(ctx, opts.Resolver, zone, dns.TypeDS)
+			if errLookup != nil {
+				return false, errLookup
+			}
+			if len(rrs) == 0 {
+				return true, nil
+			}
+			for _, sig := range sigs {
+				if d := time.Duration(sig.OrigTtl) * time.Second; d > ttl {
+					ttl = d
+				}
+			}
+			parent := dsRecords(rrs)
+			texts := make([]string, 0, len(parent))
+			for _, ds := range parent {
+				if d := time.Duration(ds.TTL) * time.Second; d > ttl {
+					ttl = d
+				}
+				texts = append(texts, ds.Text())
+			}
+			return false, fmt.Errorf("%w: %s", ErrParentDSPresent, strings.Join(texts, ", "))
+		})
+		if err != nil {
+			return report, report.stage(opts.OnStage, DNSSecStageParentDS, err,
+				"remove DS at registrar first, wait until parent stops publishing it and its TTL expires, "+
+					"then disable DNSSEC")
+		}
+		_ = report.stage(opts.OnStage, DNSSecStageParentDS, nil,
+			"parent does not publish DS, waiting DS TTL %s, lower DSTTL when DS was never published", ttl)
+
+		select {
+		case <-ctx.Done():
+			return report, report.stage(opts.OnStage, DNSSecStageDSTTL, ctx.Err(),
+				"DS TTL %s is not expired, lower DSTTL when DS was never published", ttl)
+		case <-time.After(ttl):
+		}
+		_ = report.stage(opts.OnStage, DNSSecStageDSTTL, nil, "DS TTL %s is expired", ttl)
+	}
+
+	ds, err := c.ToggleDnssec(ctx, zone, false)
+	report.DS = ds
+	if err != nil {
+		return report, report.stage(opts.OnStage, DNSSecStageDisable, err, "disable dnssec")
+	}
+	message := "dnssec is disabled"
+	if opts.Force {
+		message += ", parent DS was not checked"
+	}
+	_ = report.stage(opts.OnStage, DNSSecStageDisable, nil, message)
+	return report, nil
+}
+
+func isTemporaryDNS(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func sameDS(a, b DSRecord) bool {
+	return a.KeyTag == b.KeyTag && a.Algorithm == b.Algorithm && a.DigestType == b.DigestType &&
+		strings.EqualFold(a.Digest, b.Digest)
+}
+
+// LookupParentDS DS records of zone published by parent, TTL is set from answer
+func LookupParentDS(ctx context.Context, r DNSSecResolver, zone string) ([]DSRecord, error) {
+	rrs, _, err := lookupDNSSec(ctx, r, zone, dns.TypeDS)
+	if err != nil {
+		return nil, err
+	}
+	return dsRecords(rrs), nil
+}
+
+func dsRecords(rrs []dns.RR) []DSRecord {
+	res := make([]DSRecord, 0, len(rrs))
+	for _, rr := range rrs {
+		ds := rr.(*dns.DS)
+		res = append(res, DSRecord{Zone: strings.TrimSuffix(ds.Hdr.Name, "."), TTL: ds.Hdr.Ttl, KeyTag: ds.KeyTag,
+			Algorithm: DNSSecAlgorithm(ds.Algorithm), DigestType: DNSSecDigestType(ds.DigestType),
+			Digest: strings.ToUpper(ds.Digest)})
+	}
+	return res
+}
+
+// ValidateDNSSecChain checks that one of DNSKEY matches one of ds and signs DNSKEY set,
+// and SOA of zone is signed by DNSKEY set. Signatures must be valid now.
+func ValidateDNSSecChain(ctx context.Context, r DNSSecResolver, zone string, ds []DSRecord) error {
+	now := time.Now()
+	zone = strings.Trim(zone, ".")
+	keyRRs, keySigs, err := lookupDNSSec(ctx, r, zone, dns.TypeDNSKEY)
+	if err != nil {
+		return err
+	}
+	if len(keyRRs) == 0 {
+		return fmt.Errorf("%w: %s has no DNSKEY", ErrDNSSecChain, zone)
+	}
+	keys := make([]*dns.DNSKEY, 0, len(keyRRs))
+	for _, rr := range keyRRs {
+		keys = append(keys, rr.(*dns.DNSKEY))
+	}
+
+	var entry []*dns.DNSKEY
+	for _, key := range keys {
+		typed := DNSKeyRecord{Zone: zone, Flags: key.Flags, Protocol: key.Protocol,
+			Algorithm: DNSSecAlgorithm(key.Algorithm), PublicKey: key.PublicKey}
+		for _, d := range ds {
+			if d.Matches(typed) == nil {
+				entry = append(entry, key)
+				break
+			}
+		}
+	}
+	if len(entry) == 0 {
+		return fmt.Errorf("%w: no DNSKEY of %s matches DS", ErrDNSSecChain, zone)
+	}
+	if err = verifyRRSet(keyRRs, keySigs, entry, now); err != nil {
+		return fmt.Errorf("%w: DNSKEY of %s: %v", ErrDNSSecChain, zone, err)
+	}
+
+	soa, soaSigs, err := lookupDNSSec(ctx, r, zone, dns.TypeSOA)
+	if err != nil {
+		return err
+	}
+	if len(soa) == 0 {
+		return fmt.Errorf("%w: %s has no SOA", ErrDNSSecChain, zone)
+	}
+	if err = verifyRRSet(soa, soaSigs, keys, now); err != nil {
+		return fmt.Errorf("%w: SOA of %s: %v", ErrDNSSecChain, zone, err)
+	}
+	return nil
+}
+
+// verifyRRSet is ok when one of sigs made by one of keys is valid
+func verifyRRSet(rrs []dns.RR, sigs []*dns.RRSIG, keys []*dns.DNSKEY, now time.Time) error {
+	if len(sigs) == 0 {
+		// nolint: goerr113
+		return errors.New("no RRSIG")
+	}
+	var lastErr error
+	for _, sig := range sigs {
+		for _, key := range keys {
+			if sig.KeyTag != key.KeyTag() || sig.Algorithm != key.Algorithm {
+				continue
+			}
+			if !sig.ValidityPeriod(now) {
+				// nolint: goerr113
+				lastErr = fmt.Errorf("RRSIG of key %d is expired or not yet valid", sig.KeyTag)
+				continue
+			}
+			if lastErr = sig.Verify(key, rrs); lastErr == nil {
+				return nil
+			}
+		}
+	}
+	if lastErr == nil {
+		// nolint: goerr113
+		lastErr = errors.New("no RRSIG made by trusted keys")
+	}
+	return lastErr
+}
+
+// lookupDNSSec answers of type and their RRSIG, NXDOMAIN and NODATA are empty answers
+func lookupDNSSec(ctx context.Context, r DNSSecResolver, name string, qtype uint16) ([]dns.RR, []*dns.RRSIG, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), qtype)
+	m.SetEdns0(dnssecUDPSize, true)
+	resp, err := r.Exchange(ctx, m)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lookup %s %s: %w", name, dns.TypeToString[qtype], err)
+	}
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		// nolint: goerr113
+		return nil, nil, fmt.Errorf("lookup %s %s: %s", name, dns.TypeToString[qtype], dns.RcodeToString[resp.Rcode])
+	}
+	var (
+		rrs  []dns.RR
+		sigs []*dns.RRSIG
+	)
+	for _, rr := range resp.Answer {
+		if !strings.EqualFold(rr.Header().Name, dns.Fqdn(name)) {
+			continue
+		}
+		switch v := rr.(type) {
+		case *dns.RRSIG:
+			if v.TypeCovered == qtype {
+				sigs = append(sigs, v)
+			}
+		default:
+			if rr.Header().Rrtype == qtype {
+				rrs = append(rrs, rr)
+			}
+		}
+	}
+	return rrs, sigs, nil
+}
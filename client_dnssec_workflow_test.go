@@ -0,0 +1,301 @@
+package dnssdk
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedZone serves DNSKEY and SOA of signed zone and DS of its parent
+type signedZone struct {
+	mu      sync.Mutex
+	ksk     *dns.DNSKEY
+	zsk     *dns.DNSKEY
+	keys    []dns.RR
+	keySigs []dns.RR
+	soa     []dns.RR
+	soaSigs []dns.RR
+	// parentDS published by parent, empty when DS is not published
+	parentDS []dns.RR
+}
+
+func newSignedZone(t *testing.T, zone string) *signedZone {
+	t.Helper()
+	z := &signedZone{}
+	newKey := func(flags uint16) (*dns.DNSKEY, crypto.Signer) {
+		key := &dns.DNSKEY{Hdr: dns.RR_Header{Name: zone, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+			Flags: flags, Protocol: 3, Algorithm: dns.ECDSAP256SHA256}
+		priv, err := key.Generate(256)
+		require.NoError(t, err)
+		return key, priv.(crypto.Signer)
+	}
+	var kskPriv, zskPriv crypto.Signer
+	z.ksk, kskPriv = newKey(257)
+	z.zsk, zskPriv = newKey(256)
+	z.keys = []dns.RR{z.ksk, z.zsk}
+	z.soa = []dns.RR{&dns.SOA{Hdr: dns.RR_Header{Name: zone, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 3600},
+		Ns: "ns1." + zone, Mbox: "admin." + zone, Serial: 1, Refresh: 3600, Retry: 600, Expire: 86400, Minttl: 300}}
+
+	sign := func(key *dns.DNSKEY, priv crypto.Signer, rrs []dns.RR) dns.RR {
+		sig := &dns.RRSIG{Hdr: dns.RR_Header{Name: zone, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 3600},
+			Algorithm: key.Algorithm, SignerName: zone, KeyTag: key.KeyTag(),
+			Inception: uint32(time.Now().Add(-time.Hour).Unix()), Expiration: uint32(time.Now().Add(time.Hour).Unix())}
+		require.NoError(t, sig.Sign(priv, rrs))
+		return sig
+	}
+	z.keySigs = []dns.RR{sign(z.ksk, kskPriv, z.keys)}
+	z.soaSigs = []dns.RR{sign(z.zsk, zskPriv, z.soa)}
+	return z
+}
+
+func (z *signedZone) publishDS(ds *dns.DS) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.parentDS = nil
+	if ds != nil {
+		z.parentDS = []dns.RR{ds}
+	}
+}
+
+func (z *signedZone) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	switch req.Question[0].Qtype {
+	case dns.TypeDS:
+		resp.Answer = append(resp.Answer, z.parentDS...)
+	case dns.TypeDNSKEY:
+		resp.Answer = append(append(resp.Answer, z.keys...), z.keySigs...)
+	case dns.TypeSOA:
+		resp.Answer = append(append(resp.Answer, z.soa...), z.soaSigs...)
+	}
+	_ = w.WriteMsg(resp)
+}
+
+// serve zone on local udp port, returns resolver of it
+func (z *signedZone) serve(t *testing.T) DNSSecResolver {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: pc, Handler: z, NotifyStartedFunc: func() { close(started) }}
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return NewServerResolver(pc.LocalAddr().String())
+}
+
+// dnssecAPI serves dnssec endpoint, DS is empty for the first pending requests
+func dnssecAPI(t *testing.T, key *dns.DNSKEY, pending int) *Client {
+	t.Helper()
+	mux, client := setupTest(t)
+	ds := key.ToDS(dns.SHA256)
+	mux.HandleFunc("/v2/zones/example.com/dnssec", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPatch {
+			var body map[string]bool
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.True(t, body["enabled"])
+		}
+		if pending > 0 {
+			pending--
+			handleJSONResponse(DNSSecDS{})(rw, req)
+			return
+		}
+		handleJSONResponse(DNSSecDS{
+			Algorithm: "13", Digest: ds.Digest, DigestType: "2", Flags: 257, KeyTag: int(ds.KeyTag),
+			PublicKey: key.PublicKey, Ds: "example.com. 3600 IN DS " + strconv.Itoa(int(ds.KeyTag)) + " 13 2 " + ds.Digest,
+		})(rw, req)
+	})
+	return client
+}
+
+func TestClient_EnableDNSSec(t *testing.T) {
+	zone := newSignedZone(t, "example.com.")
+	resolver := zone.serve(t)
+	client := dnssecAPI(t, zone.ksk, 2)
+
+	// DS is published at parent while workflow waits for it
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		zone.publishDS(zone.ksk.ToDS(dns.SHA256))
+	}()
+
+	var stages []DNSSecStage
+	report, err := client.EnableDNSSec(context.Background(), "example.com.", EnableDNSSecOptions{
+		Resolver:      resolver,
+		PollInterval:  10 * time.Millisecond,
+		ParentTimeout: 5 * time.Second,
+		OnStage:       func(r DNSSecStageReport) { stages = append(stages, r.Stage) },
+	})
+	require.NoError(t, err)
+	assert.True(t, report.Secure)
+	assert.Equal(t, []DNSSecStage{DNSSecStageEnable, DNSSecStageSigned, DNSSecStageParentDS, DNSSecStageValidate}, stages)
+	assert.Equal(t, int(zone.ksk.KeyTag()), report.DS.KeyTag)
+	assert.Contains(t, report.Stages[1].Message, "publish DS at registrar: "+strconv.Itoa(int(zone.ksk.KeyTag()))+" 13 2 ")
+}
+
+func TestClient_EnableDNSSec_noResolver(t *testing.T) {
+	zone := newSignedZone(t, "example.com.")
+	client := dnssecAPI(t, zone.ksk, 0)
+
+	report, err := client.EnableDNSSec(context.Background(), "example.com", EnableDNSSecOptions{})
+	require.NoError(t, err)
+	assert.False(t, report.Secure)
+	require.Len(t, report.Stages, 2)
+	assert.Equal(t, DNSSecStageSigned, report.Stages[1].Stage)
+}
+
+func TestClient_EnableDNSSec_parentDS(t *testing.T) {
+	zone := newSignedZone(t, "example.com.")
+	resolver := zone.serve(t)
+	client := dnssecAPI(t, zone.ksk, 0)
+
+	report, err := client.EnableDNSSec(context.Background(), "example.com", EnableDNSSecOptions{Resolver: resolver})
+	require.ErrorIs(t, err, ErrDNSSecChain)
+	assert.False(t, report.Secure)
+	last := report.Stages[len(report.Stages)-1]
+	assert.Equal(t, DNSSecStageParentDS, last.Stage)
+	assert.Contains(t, last.Err.Error(), "parent has no DS")
+
+	// DS of zone signing key
+	zone.publishDS(zone.zsk.ToDS(dns.SHA256))
+	_, err = client.EnableDNSSec(context.Background(), "example.com", EnableDNSSecOptions{Resolver: resolver})
+	require.ErrorIs(t, err, ErrDNSSecChain)
+	assert.Contains(t, err.Error(), "does not match")
+}
+
+func TestValidateDNSSecChain(t *testing.T) {
+	zone := newSignedZone(t, "example.com.")
+	resolver := zone.serve(t)
+	ctx := context.Background()
+
+	zone.publishDS(zone.ksk.ToDS(dns.SHA256))
+	parent, err := LookupParentDS(ctx, resolver, "example.com")
+	require.NoError(t, err)
+	require.Len(t, parent, 1)
+	assert.Equal(t, zone.ksk.KeyTag(), parent[0].KeyTag)
+	require.NoError(t, ValidateDNSSecChain(ctx, resolver, "example.com", parent))
+
+	// DNSKEY is signed by KSK only, DS of ZSK does not validate DNSKEY set
+	zsk := zone.zsk.ToDS(dns.SHA256)
+	err = ValidateDNSSecChain(ctx, resolver, "example.com", []DSRecord{{KeyTag: zsk.KeyTag,
+		Algorithm: AlgorithmECDSAP256SHA256, DigestType: DigestSHA256, Digest: zsk.Digest}})
+	assert.ErrorIs(t, err, ErrDNSSecChain)
+
+	// broken signature of SOA
+	zone.mu.Lock()
+	zone.soa[0].(*dns.SOA).Serial = 2
+	zone.mu.Unlock()
+	err = ValidateDNSSecChain(ctx, resolver, "example.com", parent)
+	assert.ErrorIs(t, err, ErrDNSSecChain)
+	assert.Contains(t, err.Error(), "SOA")
+}
+
+// disableAPI counts requests disabling dnssec
+func disableAPI(t *testing.T) (*Client, *int) {
+	t.Helper()
+	mux, client := setupTest(t)
+	disabled := 0
+	mux.HandleFunc("/v2/zones/example.com/dnssec", func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, http.MethodPatch, req.Method)
+		var body map[string]bool
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.False(t, body["enabled"])
+		disabled++
+		handleJSONResponse(DNSSecDS{})(rw, req)
+	})
+	return client, &disabled
+}
+
+func TestClient_DisableDNSSec(t *testing.T) {
+	zone := newSignedZone(t, "example.com.")
+	resolver := zone.serve(t)
+	client, disabled := disableAPI(t)
+	ds := zone.ksk.ToDS(dns.SHA256)
+	// TTL of answer is decremented by resolver, original TTL of RRSIG is waited
+	ds.Hdr.Ttl = 0
+	zone.publishDS(ds)
+	zone.mu.Lock()
+	zone.parentDS = append(zone.parentDS, &dns.RRSIG{
+		Hdr:         dns.RR_Header{Name: ds.Hdr.Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET},
+		TypeCovered: dns.TypeDS, Algorithm: dns.ECDSAP256SHA256, OrigTtl: 1, SignerName: "com.",
+	})
+	zone.mu.Unlock()
+
+	report, err := client.DisableDNSSec(context.Background(), "example.com", DisableDNSSecOptions{Resolver: resolver})
+	require.ErrorIs(t, err, ErrParentDSPresent)
+	assert.Contains(t, err.Error(), strconv.Itoa(int(ds.KeyTag)))
+	assert.Equal(t, 0, *disabled)
+	require.Len(t, report.Stages, 1)
+	assert.Contains(t, report.Stages[0].Message, "remove DS at registrar first")
+
+	// DS is removed at registrar while workflow waits for it
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		zone.publishDS(nil)
+	}()
+	start := time.Now()
+	var stages []DNSSecStage
+	_, err = client.DisableDNSSec(context.Background(), "example.com.", DisableDNSSecOptions{
+		Resolver:      resolver,
+		PollInterval:  10 * time.Millisecond,
+		ParentTimeout: 5 * time.Second,
+		DSTTL:         10 * time.Millisecond,
+		OnStage:       func(r DNSSecStageReport) { stages = append(stages, r.Stage) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, *disabled)
+	assert.Equal(t, []DNSSecStage{DNSSecStageParentDS, DNSSecStageDSTTL, DNSSecStageDisable}, stages)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "DS TTL is waited")
+}
+
+func TestClient_DisableDNSSec_force(t *testing.T) {
+	zone := newSignedZone(t, "example.com.")
+	resolver := zone.serve(t)
+	client, disabled := disableAPI(t)
+	zone.publishDS(zone.ksk.ToDS(dns.SHA256))
+
+	_, err := client.DisableDNSSec(context.Background(), "example.com", DisableDNSSecOptions{})
+	require.ErrorContains(t, err, "resolver is required")
+	assert.Equal(t, 0, *disabled)
+
+	report, err := client.DisableDNSSec(context.Background(), "example.com",
+		DisableDNSSecOptions{Resolver: resolver, Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, *disabled)
+	require.Len(t, report.Stages, 1)
+	assert.Contains(t, report.Stages[0].Message, "parent DS was not checked")
+
+	// DS could be removed before the first check, it is still cached by resolvers
+	zone.publishDS(nil)
+	start := time.Now()
+	report, err = client.DisableDNSSec(context.Background(), "example.com",
+		DisableDNSSecOptions{Resolver: resolver, DSTTL: 100 * time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, 2, *disabled)
+	require.Len(t, report.Stages, 3)
+	assert.Equal(t, DNSSecStageDSTTL, report.Stages[1].Stage)
+	assert.Equal(t, "DS TTL 100ms is expired", report.Stages[1].Message)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "DS TTL is waited")
+
+	// default DS TTL is waited until context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	report, err = client.DisableDNSSec(ctx, "example.com", DisableDNSSecOptions{Resolver: resolver})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 2, *disabled)
+	assert.Equal(t, "parent does not publish DS, waiting DS TTL 24h0m0s, lower DSTTL when DS was never published",
+		report.Stages[0].Message)
+	assert.Equal(t, "DS TTL 24h0m0s is not expired, lower DSTTL when DS was never published", report.Stages[1].Message)
+}
@@ -0,0 +1,107 @@
+package dnssdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Change write request intercepted by dry-run mode
+type Change struct {
+	Method  string
+	Path    string
+	Payload json.RawMessage
+	At      time.Time
+}
+
+// WithDryRun sends GET requests as usual but records every write request in change log instead of sending it.
+// Writes return synthetic results: fake ids for created items, updates echo ids of items created in dry-run
+// and zero for others, and successful import.
+func WithDryRun() func(*Client) {
+	return func(client *Client) {
+		client.dryRun = &dryRunLog{}
+	}
+}
+
+// Changes returns write requests intercepted in dry-run mode
+func (c *Client) Changes() []Change {
+	if c.dryRun == nil {
+		return nil
+	}
+	c.dryRun.mu.Lock()
+	defer c.dryRun.mu.Unlock()
+
+	res := make([]Change, len(c.dryRun.changes))
+	copy(res, c.dryRun.changes)
+	return res
+}
+
+// ResetChanges clears change log of dry-run mode
+func (c *Client) ResetChanges() {
+	if c.dryRun == nil {
+		return
+	}
+	c.dryRun.mu.Lock()
+	defer c.dryRun.mu.Unlock()
+
+	c.dryRun.changes = nil
+}
+
+type dryRunLog struct {
+	mu      sync.Mutex
+	changes []Change
+	lastID  uint64
+	// ids of items created in dry-run by their uri
+	ids map[string]uint64
+}
+
+// record change and fill dest with synthetic result
+func (d *dryRunLog) record(method, uri string, payload []byte, dest interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	change := Change{Method: method, Path: uri, At: time.Now()}
+	if len(payload) > 0 {
+		change.Payload = append(json.RawMessage{}, payload...)
+	}
+	d.changes = append(d.changes, change)
+
+	switch v := dest.(type) {
+	case *CreateResponse:
+		*v = CreateResponse{ID: d.id(method, uri, payload)}
+	case *CreateNetworkMappingResponse:
+		*v = CreateNetworkMappingResponse{ID: d.id(method, uri, payload)}
+	case *ImportZoneResponse:
+		imported := ImportZone{}
+		_ = json.Unmarshal(payload, &imported)
+		records := 0
+		for _, line := range strings.Split(imported.Content, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, ";") && !strings.HasPrefix(line, "$") {
+				records++
+			}
+		}
+		*v = ImportZoneResponse{Success: true, Imported: ImportedStats{ResourceRecords: records}}
+	}
+}
+
+// id minted for created item, updates of it echo the same id, other writes get zero
+func (d *dryRunLog) id(method, uri string, payload []byte) uint64 {
+	if method != http.MethodPost {
+		return d.ids[uri]
+	}
+	var item struct {
+		Name string `json:"name"`
+	}
+	_ = json.Unmarshal(payload, &item)
+	d.lastID++
+	if item.Name != "" {
+		if d.ids == nil {
+			d.ids = map[string]uint64{}
+		}
+		d.ids[strings.TrimSuffix(uri, "/")+"/"+item.Name] = d.lastID
+	}
+	return d.lastID
+}
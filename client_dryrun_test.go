@@ -0,0 +1,71 @@
+package dnssdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithDryRun(t *testing.T) {
+	mux, client := setupTest(t)
+	WithDryRun()(client)
+
+	mux.Handle("/v2/zones/example.com", validationHandler{
+		method: http.MethodGet,
+		next:   handleJSONResponse(Zone{Name: "example.com"}),
+	})
+	mux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL)
+	})
+
+	ctx := context.Background()
+	zone, err := client.Zone(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+
+	id, err := client.CreateZone(ctx, AddZone{Name: "example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), id)
+
+	id, err = client.UpdateZone(ctx, "example.org", AddZone{Name: "example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), id, "id of zone created in dry-run")
+
+	err = client.UpdateRRSet(ctx, "example.com", "www.example.com", "A",
+		RRSet{TTL: 60, Records: []ResourceRecord{{Content: []any{"192.0.2.1"}}}})
+	require.NoError(t, err)
+
+	_, err = client.ToggleDnssec(ctx, "example.com", true)
+	require.NoError(t, err)
+
+	mappingID, err := client.CreateNetworkMapping(ctx, NetworkMappingRequest{Name: "office"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), mappingID)
+
+	imported, err := client.ImportZone(ctx, "example.org", "$ORIGIN example.org.\n; comment\nwww 60 IN A 192.0.2.1\n")
+	require.NoError(t, err)
+	assert.True(t, imported.Success)
+	assert.Equal(t, 1, imported.Imported.ResourceRecords)
+
+	require.NoError(t, client.DeleteZone(ctx, "example.org"))
+
+	changes := client.Changes()
+	require.Len(t, changes, 7)
+	assert.Equal(t, http.MethodPost, changes[0].Method)
+	assert.Equal(t, "/v2/zones", changes[0].Path)
+	assert.JSONEq(t, `{"name":"example.org"}`, string(changes[0].Payload))
+	assert.Equal(t, http.MethodPut, changes[1].Method)
+	assert.Equal(t, "/v2/zones/example.org", changes[1].Path)
+	assert.Equal(t, "/v2/zones/example.com/www.example.com/A", changes[2].Path)
+	assert.JSONEq(t, `{"type":"","ttl":60,"resource_records":[{"content":["192.0.2.1"],"meta":null,"enabled":false}],`+
+		`"filters":null,"meta":null}`, string(changes[2].Payload))
+	assert.JSONEq(t, `{"enabled":true}`, string(changes[3].Payload))
+	assert.Equal(t, http.MethodDelete, changes[6].Method)
+	assert.Nil(t, changes[6].Payload)
+
+	client.ResetChanges()
+	assert.Empty(t, client.Changes())
+}
@@ -1,12 +1,14 @@
 package dnssdk
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"math"
 	"net"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ListZones dto to read list of zones from API
@@ -294,6 +296,18 @@ type RRSets struct {
 	RRSets []RRSet `json:"rrsets"`
 }
 
+// ZoneRRSet dto of RRSet with its name from list of zone rrsets
+type ZoneRRSet struct {
+	Name string `json:"name"`
+	RRSet
+}
+
+// ZoneRRSets dto to read list of zone rrsets from API
+type ZoneRRSets struct {
+	RRSets      []ZoneRRSet `json:"rrsets"`
+	TotalAmount int         `json:"total_amount"`
+}
+
 // ResourceRecord dto describe records in RRSet
 type ResourceRecord struct {
 	Content []any          `json:"content"`
@@ -412,11 +426,19 @@ func (caa RecordTypeCAA) ToContent() []any {
 	if len(parts) < 3 {
 		return nil
 	}
-	content := make([]any, len(parts))
+	// nolint: gomnd
+	content := make([]any, 3)
 	// nolint: gomnd
 	content[1] = parts[1]
+	// value is quoted in zone file presentation
+	value := strings.Join(parts[2:], " ")
+	if len(value) > 1 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		if unquoted, err := ParseTXT(value); err == nil {
+			value = unquoted
+		}
+	}
 	// nolint: gomnd
-	content[2] = strings.Join(parts[2:], " ")
+	content[2] = value
 	// nolint: gomnd
 	content[0], _ = strconv.ParseInt(parts[0], 10, 64)
 
@@ -522,6 +544,16 @@ func ToRecordType(rType, content string) RecordType {
 		return RecordTypeSRV(content)
 	case "https", "scvb":
 		return RecordTypeHTTPS_SCVB(content)
+	case "tlsa":
+		return RecordTypeTLSA(content)
+	case "sshfp":
+		return RecordTypeSSHFP(content)
+	case "naptr":
+		return RecordTypeNAPTR(content)
+	case "uri":
+		return RecordTypeURI(content)
+	case "cert":
+		return RecordTypeCERT(content)
 	}
 	return RecordTypeAny(content)
 }
@@ -816,3 +848,197 @@ type ListNetworkMappingResponse struct {
 type CreateNetworkMappingResponse struct {
 	ID uint64 `json:"id"`
 }
+
+// TSIG algorithms supported for signing zone transfers
+const (
+	TSIGAlgorithmHMACMD5    = "hmac-md5"
+	TSIGAlgorithmHMACSHA1   = "hmac-sha1"
+	TSIGAlgorithmHMACSHA224 = "hmac-sha224"
+	TSIGAlgorithmHMACSHA256 = "hmac-sha256"
+	TSIGAlgorithmHMACSHA384 = "hmac-sha384"
+	TSIGAlgorithmHMACSHA512 = "hmac-sha512"
+)
+
+// Transfer states of secondary zone
+const (
+	TransferStatePending = "pending"
+	TransferStateOK      = "ok"
+	TransferStateFailed  = "failed"
+)
+
+const defaultDNSPort = 53
+
+// TSIGKey dto describe key used to sign zone transfers and notifies
+type TSIGKey struct {
+	ID        uint64 `json:"id,omitempty"`
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+	Secret    string `json:"secret,omitempty"` // base64, API does not return it back
+}
+
+// Validate TSIGKey before sending to API
+func (k TSIGKey) Validate() error {
+	if strings.Trim(k.Name, ".") == "" {
+		// nolint: goerr113
+		return fmt.Errorf("tsig key: name is empty")
+	}
+	if !k.Known() {
+		// nolint: goerr113
+		return fmt.Errorf("tsig key %s: unsupported algorithm %q", k.Name, k.Algorithm)
+	}
+	if k.Secret == "" {
+		// nolint: goerr113
+		return fmt.Errorf("tsig key %s: secret is empty", k.Name)
+	}
+	if _, err := base64.StdEncoding.DecodeString(k.Secret); err != nil {
+		return fmt.Errorf("tsig key %s: secret is not base64: %w", k.Name, err)
+	}
+	return nil
+}
+
+// Known reports whether algorithm of key is one of TSIGAlgorithm constants in any case,
+// keys listed from API are returned as is and can use algorithm unknown to SDK
+func (k TSIGKey) Known() bool {
+	_, ok := tsigAlgorithms[k.normalized().Algorithm]
+	return ok
+}
+
+// normalized key with canonical lower case algorithm
+func (k TSIGKey) normalized() TSIGKey {
+	k.Algorithm = strings.ToLower(strings.TrimSpace(k.Algorithm))
+	return k
+}
+
+// ListTSIGKeys dto to read list of tsig keys from API
+type ListTSIGKeys struct {
+	TSIGKeys    []TSIGKey `json:"tsig_keys"`
+	TotalAmount int       `json:"total_amount"`
+}
+
+// PrimaryServer dto describe primary server the secondary zone is transferred from
+type PrimaryServer struct {
+	Address string `json:"address"`
+	Port    uint16 `json:"port,omitempty"`     // 53 when empty
+	TSIGKey string `json:"tsig_key,omitempty"` // name of TSIGKey
+}
+
+// Validate PrimaryServer
+func (p PrimaryServer) Validate() error {
+	if net.ParseIP(p.Address) == nil {
+		// nolint: goerr113
+		return fmt.Errorf("primary server: wrong ip %q", p.Address)
+	}
+	return nil
+}
+
+// HostPort of primary server
+func (p PrimaryServer) HostPort() string {
+	port := p.Port
+	if port == 0 {
+		port = defaultDNSPort
+	}
+	return net.JoinHostPort(p.Address, strconv.Itoa(int(port)))
+}
+
+// NotifySettings dto describe how secondary zone reacts on NOTIFY from primaries
+type NotifySettings struct {
+	Enabled bool `json:"enabled"`
+	// AllowFrom ip or cidr, primary servers are allowed when empty
+	AllowFrom []string `json:"allow_from,omitempty"`
+}
+
+// Validate NotifySettings
+func (n NotifySettings) Validate() error {
+	for _, v := range n.AllowFrom {
+		if _, _, err := net.ParseCIDR(v); err == nil {
+			continue
+		}
+		if net.ParseIP(v) == nil {
+			// nolint: goerr113
+			return fmt.Errorf("notify: wrong allow_from %q", v)
+		}
+	}
+	return nil
+}
+
+// AddSecondaryZone dto to create or update secondary zone
+type AddSecondaryZone struct {
+	Name           string                 `json:"name"`
+	Enabled        *bool                  `json:"enabled,omitempty"` // nil keeps API default
+	Meta           map[string]interface{} `json:"meta,omitempty"`
+	PrimaryServers []PrimaryServer        `json:"primary_servers"`
+	Notify         *NotifySettings        `json:"notify,omitempty"`
+}
+
+// Validate AddSecondaryZone before sending to API
+func (z AddSecondaryZone) Validate() error {
+	if strings.Trim(z.Name, ".") == "" {
+		// nolint: goerr113
+		return fmt.Errorf("secondary zone: name is empty")
+	}
+	return validateSecondary(z.Name, z.PrimaryServers, z.Notify)
+}
+
+// SecondaryZone dto to read secondary zone info from API
+type SecondaryZone struct {
+	ID             uint64                 `json:"id,omitempty"`
+	Name           string                 `json:"name"`
+	ClientID       uint64                 `json:"client_id"`
+	Enabled        bool                   `json:"enabled"`
+	Meta           map[string]interface{} `json:"meta"`
+	PrimaryServers []PrimaryServer        `json:"primary_servers"`
+	Notify         *NotifySettings        `json:"notify,omitempty"`
+	Transfer       TransferStatus         `json:"transfer"`
+}
+
+// Validate SecondaryZone returned from API
+func (z SecondaryZone) Validate() error {
+	if z.Name == "" {
+		// nolint: goerr113
+		return fmt.Errorf("secondary zone: name is empty")
+	}
+	return validateSecondary(z.Name, z.PrimaryServers, z.Notify)
+}
+
+func validateSecondary(name string, primaries []PrimaryServer, notify *NotifySettings) error {
+	if len(primaries) == 0 {
+		// nolint: goerr113
+		return fmt.Errorf("secondary zone %s: primary servers are empty", name)
+	}
+	seen := make(map[string]struct{}, len(primaries))
+	for _, p := range primaries {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("secondary zone %s: %w", name, err)
+		}
+		if _, ok := seen[p.HostPort()]; ok {
+			// nolint: goerr113
+			return fmt.Errorf("secondary zone %s: duplicated primary server %s", name, p.HostPort())
+		}
+		seen[p.HostPort()] = struct{}{}
+	}
+	if notify != nil {
+		if err := notify.Validate(); err != nil {
+			return fmt.Errorf("secondary zone %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// TransferStatus dto describe last zone transfer from primary servers
+type TransferStatus struct {
+	State         string    `json:"state"`
+	Serial        uint64    `json:"serial"`
+	PrimaryServer string    `json:"primary_server,omitempty"` // address of the last used primary
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+	LastSuccessAt time.Time `json:"last_success_at"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// Known is true for states of this client, unknown states of newer API are passed through as is
+func (s TransferStatus) Known() bool {
+	switch s.State {
+	case TransferStatePending, TransferStateOK, TransferStateFailed:
+		return true
+	}
+	return false
+}
@@ -46,6 +46,11 @@ func TestRecordTypeCAA_ToContent(t *testing.T) {
 			caa:  "10 issue aaa",
 			want: []any{int64(10), "issue", "aaa"},
 		},
+		{
+			name: "quoted",
+			caa:  `0 issue "ca.example.net; account=230123"`,
+			want: []any{int64(0), "issue", "ca.example.net; account=230123"},
+		},
 		{
 			name: "wrong",
 			caa:  "10 aa",
@@ -922,3 +927,105 @@ func TestIPNet_MarshalUnmarshalJSON(t *testing.T) {
 		assert.Equal(t, []byte(`""`), marshaled)
 	})
 }
+
+func TestTSIGKey_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     TSIGKey
+		wantErr string
+	}{
+		{
+			name: "ok",
+			key:  TSIGKey{Name: "key", Algorithm: "HMAC-SHA256", Secret: "c2VjcmV0"},
+		},
+		{
+			name:    "empty name",
+			key:     TSIGKey{Algorithm: TSIGAlgorithmHMACSHA256, Secret: "c2VjcmV0"},
+			wantErr: "tsig key: name is empty",
+		},
+		{
+			name:    "unknown algorithm",
+			key:     TSIGKey{Name: "key", Algorithm: "gss-tsig", Secret: "c2VjcmV0"},
+			wantErr: `tsig key key: unsupported algorithm "gss-tsig"`,
+		},
+		{
+			name:    "empty secret",
+			key:     TSIGKey{Name: "key", Algorithm: TSIGAlgorithmHMACSHA256},
+			wantErr: "tsig key key: secret is empty",
+		},
+		{
+			name:    "not base64 secret",
+			key:     TSIGKey{Name: "key", Algorithm: TSIGAlgorithmHMACSHA256, Secret: "not base64!"},
+			wantErr: "tsig key key: secret is not base64: illegal base64 data at input byte 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAddSecondaryZone_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    AddSecondaryZone
+		wantErr string
+	}{
+		{
+			name: "ok",
+			zone: AddSecondaryZone{
+				Name:           "example.com",
+				PrimaryServers: []PrimaryServer{{Address: "192.0.2.1"}, {Address: "192.0.2.1", Port: 5353}},
+				Notify:         &NotifySettings{Enabled: true, AllowFrom: []string{"192.0.2.0/24", "2001:db8::1"}},
+			},
+		},
+		{
+			name:    "empty name",
+			zone:    AddSecondaryZone{Name: ".", PrimaryServers: []PrimaryServer{{Address: "192.0.2.1"}}},
+			wantErr: "secondary zone: name is empty",
+		},
+		{
+			name:    "wrong primary",
+			zone:    AddSecondaryZone{Name: "example.com", PrimaryServers: []PrimaryServer{{Address: "ns1.example.net"}}},
+			wantErr: `secondary zone example.com: primary server: wrong ip "ns1.example.net"`,
+		},
+		{
+			name: "duplicated primary",
+			zone: AddSecondaryZone{
+				Name:           "example.com",
+				PrimaryServers: []PrimaryServer{{Address: "192.0.2.1"}, {Address: "192.0.2.1", Port: 53}},
+			},
+			wantErr: "secondary zone example.com: duplicated primary server 192.0.2.1:53",
+		},
+		{
+			name: "wrong notify",
+			zone: AddSecondaryZone{
+				Name:           "example.com",
+				PrimaryServers: []PrimaryServer{{Address: "192.0.2.1"}},
+				Notify:         &NotifySettings{AllowFrom: []string{"any"}},
+			},
+			wantErr: `secondary zone example.com: notify: wrong allow_from "any"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.zone.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPrimaryServer_HostPort(t *testing.T) {
+	assert.Equal(t, "192.0.2.1:53", PrimaryServer{Address: "192.0.2.1"}.HostPort())
+	assert.Equal(t, "[2001:db8::1]:5353", PrimaryServer{Address: "2001:db8::1", Port: 5353}.HostPort())
+}
@@ -0,0 +1,395 @@
+package dnssdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity of lint finding
+type Severity int
+
+// severities of lint findings, from the lowest
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// String implementation
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// lint rule codes
+const (
+	LintCNAMECoexist  = "DNS001"
+	LintCNAMEApex     = "DNS002"
+	LintTargetIsCNAME = "DNS003"
+	LintDanglingCNAME = "DNS004"
+	LintTTLMismatch   = "DNS005"
+	LintCAAIodef      = "DNS006"
+	LintSPFLookups    = "DNS007"
+	LintSPFMultiple   = "DNS008"
+	// codes of ValidateCidrLabels findings
+	LintCidrLabelUnknown  = "DNS009"
+	LintCidrTagUnused     = "DNS010"
+	LintCidrTagShadowed   = "DNS011"
+	LintRecordUnreachable = "DNS012"
+)
+
+const (
+	spfLookupsLimit = 10
+	spfPrefix       = "v=spf1"
+	lintCNAME       = "CNAME"
+	lintMX          = "MX"
+	lintSRV         = "SRV"
+	lintCAA         = "CAA"
+	lintTXT         = "TXT"
+	lintA           = "A"
+	lintAAAA        = "AAAA"
+)
+
+// Finding of lint rule
+type Finding struct {
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// String as "error DNS001 www.example.com CNAME: message"
+func (f Finding) String() string {
+	subject := f.Name
+	if f.Type != "" {
+		subject += " " + f.Type
+	}
+	return fmt.Sprintf("%s %s %s: %s", f.Severity, f.Code, subject, f.Message)
+}
+
+// LintRule checks zone, Check returns findings with empty Code and Severity filled by Linter
+type LintRule struct {
+	Code        string
+	Severity    Severity
+	Description string
+	Check       func(zone *LintZone) []Finding
+}
+
+// LintZone zone prepared for rules: names are lower case without trailing dot
+type LintZone struct {
+	Name   string
+	RRSets []SnapshotRRSet
+	byName map[string][]SnapshotRRSet
+}
+
+// Lookup rrsets of name
+func (z *LintZone) Lookup(name string) []SnapshotRRSet {
+	return z.byName[normalizeName(name)]
+}
+
+// InZone is true for zone apex and its subdomains
+func (z *LintZone) InZone(name string) bool {
+	name = normalizeName(name)
+	return name == z.Name || strings.HasSuffix(name, "."+z.Name)
+}
+
+// Linter runs rules over zones
+type Linter struct {
+	rules []LintRule
+}
+
+// NewLinter with rules, DefaultLintRules when nothing is passed
+func NewLinter(rules ...LintRule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultLintRules()
+	}
+	return &Linter{rules: rules}
+}
+
+// Add rule to linter
+func (l *Linter) Add(rule LintRule) *Linter {
+	l.rules = append(l.rules, rule)
+	return l
+}
+
+// Rules of linter
+func (l *Linter) Rules() []LintRule {
+	return append([]LintRule{}, l.rules...)
+}
+
+// Lint zone, findings are sorted from the most severe one
+func (l *Linter) Lint(zone Snapshot) []Finding {
+	lz := &LintZone{Name: normalizeName(zone.Zone), byName: map[string][]SnapshotRRSet{}}
+	for _, set := range zone.RRSets {
+		set.Name = normalizeName(set.Name)
+		set.Type = strings.ToUpper(set.Type)
+		lz.RRSets = append(lz.RRSets, set)
+		lz.byName[set.Name] = append(lz.byName[set.Name], set)
+	}
+
+	var res []Finding
+	for _, rule := range l.rules {
+		for _, f := range rule.Check(lz) {
+			if f.Code == "" {
+				f.Code = rule.Code
+				f.Severity = rule.Severity
+			}
+			res = append(res, f)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Severity != res[j].Severity {
+			return res[i].Severity > res[j].Severity
+		}
+		if res[i].Name != res[j].Name {
+			return res[i].Name < res[j].Name
+		}
+		return res[i].Code < res[j].Code
+	})
+	return res
+}
+
+// Lint zone with default rules
+func Lint(zone Snapshot) []Finding {
+	return NewLinter().Lint(zone)
+}
+
+// DefaultLintRules rules of common misconfigurations
+func DefaultLintRules() []LintRule {
+	return []LintRule{
+		{Code: LintCNAMECoexist, Severity: SeverityError,
+			Description: "CNAME can not coexist with other types at the same name", Check: lintCNAMECoexist},
+		{Code: LintCNAMEApex, Severity: SeverityError,
+			Description: "CNAME at zone apex conflicts with SOA and NS", Check: lintCNAMEApex},
+		{Code: LintTargetIsCNAME, Severity: SeverityError,
+			Description: "MX and SRV targets must not be CNAME", Check: lintTargetIsCNAME},
+		{Code: LintDanglingCNAME, Severity: SeverityWarning,
+			Description: "CNAME target inside zone has no records", Check: lintDanglingCNAME},
+		{Code: LintTTLMismatch, Severity: SeverityWarning,
+			Description: "A and AAAA of the same name have different TTL", Check: lintTTLMismatch},
+		{Code: LintCAAIodef, Severity: SeverityInfo,
+			Description: "CAA without iodef, violations are not reported", Check: lintCAAIodef},
+		{Code: LintSPFLookups, Severity: SeverityError,
+			Description: "SPF needs more than 10 DNS lookups", Check: lintSPFLookups},
+		{Code: LintSPFMultiple, Severity: SeverityError,
+			Description: "name has more than one SPF record", Check: lintSPFMultiple},
+	}
+}
+
+func lintCNAMECoexist(z *LintZone) []Finding {
+	var res []Finding
+	for _, set := range z.RRSets {
+		if set.Type != lintCNAME {
+			continue
+		}
+		var others []string
+		for _, other := range z.byName[set.Name] {
+			if other.Type != lintCNAME {
+				others = append(others, other.Type)
+			}
+		}
+		if len(others) > 0 {
+			res = append(res, Finding{Name: set.Name, Type: set.Type,
+				Message: "CNAME coexists with " + strings.Join(others, ", ")})
+		}
+	}
+	return res
+}
+
+func lintCNAMEApex(z *LintZone) []Finding {
+	for _, set := range z.byName[z.Name] {
+		if set.Type == lintCNAME {
+			return []Finding{{Name: set.Name, Type: set.Type, Message: "CNAME at zone apex"}}
+		}
+	}
+	return nil
+}
+
+func lintTargetIsCNAME(z *LintZone) []Finding {
+	var res []Finding
+	for _, set := range z.RRSets {
+		if set.Type != lintMX && set.Type != lintSRV {
+			continue
+		}
+		for _, r := range set.Records {
+			target := recordTarget(set.Type, r)
+			if target == "" || !z.InZone(target) {
+				continue
+			}
+			for _, t := range z.Lookup(target) {
+				if t.Type == lintCNAME {
+					res = append(res, Finding{Name: set.Name, Type: set.Type,
+						Message: fmt.Sprintf("target %s is CNAME", target)})
+				}
+			}
+		}
+	}
+	return res
+}
+
+func lintDanglingCNAME(z *LintZone) []Finding {
+	var res []Finding
+	for _, set := range z.RRSets {
+		if set.Type != lintCNAME {
+			continue
+		}
+		for _, r := range set.Records {
+			target := normalizeName(r.ContentToString())
+			if target == "" || !z.InZone(target) || strings.HasPrefix(target, "*.") || z.hasWildcard(target) {
+				continue
+			}
+			if len(z.Lookup(target)) == 0 {
+				res = append(res, Finding{Name: set.Name, Type: set.Type,
+					Message: fmt.Sprintf("target %s has no records", target)})
+			}
+		}
+	}
+	return res
+}
+
+// hasWildcard rrsets covering name
+func (z *LintZone) hasWildcard(name string) bool {
+	for name != z.Name {
+		i := strings.IndexByte(name, '.')
+		if i < 0 {
+			return false
+		}
+		name = name[i+1:]
+		if len(z.byName["*."+name]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func lintTTLMismatch(z *LintZone) []Finding {
+	var res []Finding
+	names := make([]string, 0, len(z.byName))
+	for name := range z.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ttls := map[string]int{}
+		for _, set := range z.byName[name] {
+			if set.Type == lintA || set.Type == lintAAAA {
+				ttls[set.Type] = set.TTL
+			}
+		}
+		v4, okV4 := ttls[lintA]
+		v6, okV6 := ttls[lintAAAA]
+		if okV4 && okV6 && v4 != v6 {
+			res = append(res, Finding{Name: name,
+				Message: fmt.Sprintf("A ttl %d differs from AAAA ttl %d", v4, v6)})
+		}
+	}
+	return res
+}
+
+func lintCAAIodef(z *LintZone) []Finding {
+	var res []Finding
+	for _, set := range z.RRSets {
+		if set.Type != lintCAA {
+			continue
+		}
+		issues, iodef := false, false
+		for _, r := range set.Records {
+			fields := strings.Fields(r.ContentToString())
+			// nolint: gomnd
+			if len(fields) < 2 {
+				continue
+			}
+			switch strings.ToLower(fields[1]) {
+			case "iodef":
+				iodef = true
+			case "issue", "issuewild":
+				issues = true
+			}
+		}
+		if issues && !iodef {
+			res = append(res, Finding{Name: set.Name, Type: set.Type, Message: "CAA has no iodef record"})
+		}
+	}
+	return res
+}
+
+func lintSPFLookups(z *LintZone) []Finding {
+	var res []Finding
+	for _, set := range z.RRSets {
+		for _, spf := range spfRecords(set) {
+			if n := spfDirectLookups(spf); n > spfLookupsLimit {
+				res = append(res, Finding{Name: set.Name, Type: set.Type,
+					Message: fmt.Sprintf("SPF needs at least %d lookups, limit is %d", n, spfLookupsLimit)})
+			}
+		}
+	}
+	return res
+}
+
+func lintSPFMultiple(z *LintZone) []Finding {
+	var res []Finding
+	for _, set := range z.RRSets {
+		if n := len(spfRecords(set)); n > 1 {
+			res = append(res, Finding{Name: set.Name, Type: set.Type,
+				Message: fmt.Sprintf("%d SPF records, receivers fail with permerror", n)})
+		}
+	}
+	return res
+}
+
+func spfRecords(set SnapshotRRSet) []string {
+	if set.Type != lintTXT {
+		return nil
+	}
+	var res []string
+	for _, r := range set.Records {
+		content := r.ContentToString()
+		if DetectTXT(set.Name, content) == TXTSPF {
+			res = append(res, content)
+		}
+	}
+	return res
+}
+
+// spfDirectLookups mechanisms and modifiers of record causing DNS lookup, includes are not followed
+func spfDirectLookups(spf string) int {
+	n := 0
+	for _, term := range strings.Fields(spf)[1:] {
+		term = strings.ToLower(strings.TrimLeft(term, "+-~?"))
+		name := term
+		if i := strings.IndexAny(term, ":/="); i >= 0 {
+			name = term[:i]
+		}
+		switch name {
+		case "include", "a", "mx", "ptr", "exists", "redirect":
+			n++
+		}
+	}
+	return n
+}
+
+// recordTarget host name of MX or SRV record
+func recordTarget(recordType string, r ResourceRecord) string {
+	fields := strings.Fields(r.ContentToString())
+	// nolint: gomnd
+	switch {
+	case recordType == lintMX && len(fields) == 2:
+		return normalizeName(fields[1])
+	case recordType == lintSRV && len(fields) == 4:
+		return normalizeName(fields[3])
+	}
+	return ""
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
@@ -0,0 +1,97 @@
+package dnssdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintZoneFile = `$ORIGIN example.com.
+$TTL 300
+@        IN SOA ns1.example.com. admin.example.com. 1 3600 600 86400 300
+@        IN NS  ns1.example.com.
+@        IN MX  10 mail
+@        IN TXT "v=spf1 include:a.com include:b.com include:c.com include:d.com include:e.com a mx ptr exists:x.com include:f.com redirect=g.com -all"
+@        IN TXT "v=spf1 -all"
+@        IN CAA 0 issue "letsencrypt.org"
+mail     IN CNAME mx.example.net.
+_sip._tcp IN SRV 10 5 5060 sip
+sip      IN CNAME sip.example.net.
+www      IN CNAME web
+www      IN TXT "verification"
+docs     IN CNAME missing
+wild     IN CNAME x.any
+*.any    IN A 192.0.2.9
+host     60 IN A 192.0.2.1
+host     300 IN AAAA 2001:db8::1
+ok       300 IN A 192.0.2.2
+ok       300 IN AAAA 2001:db8::2
+`
+
+func lintCodes(findings []Finding) map[string][]string {
+	res := map[string][]string{}
+	for _, f := range findings {
+		res[f.Code] = append(res[f.Code], f.Name)
+	}
+	return res
+}
+
+func TestLint_zoneFile(t *testing.T) {
+	transfer, err := ParseZoneFile("example.com", strings.NewReader(lintZoneFile))
+	require.NoError(t, err)
+
+	findings := Lint(transfer.Snapshot())
+	codes := lintCodes(findings)
+
+	assert.Equal(t, []string{"www.example.com"}, codes[LintCNAMECoexist])
+	assert.Empty(t, codes[LintCNAMEApex])
+	assert.Equal(t, []string{"_sip._tcp.example.com", "example.com"}, codes[LintTargetIsCNAME])
+	assert.Equal(t, []string{"docs.example.com", "www.example.com"}, codes[LintDanglingCNAME])
+	assert.Equal(t, []string{"host.example.com"}, codes[LintTTLMismatch])
+	assert.Equal(t, []string{"example.com"}, codes[LintCAAIodef])
+	assert.Equal(t, []string{"example.com"}, codes[LintSPFLookups])
+	assert.Equal(t, []string{"example.com"}, codes[LintSPFMultiple])
+
+	// the most severe first
+	assert.Equal(t, SeverityError, findings[0].Severity)
+	assert.Equal(t, SeverityInfo, findings[len(findings)-1].Severity)
+	assert.Equal(t, "info DNS006 example.com CAA: CAA has no iodef record", findings[len(findings)-1].String())
+}
+
+func TestLint_apexCNAME(t *testing.T) {
+	findings := Lint(Snapshot{Zone: "example.com.", RRSets: []SnapshotRRSet{
+		{Name: "Example.com.", Type: "cname", TTL: 60, Records: []ResourceRecord{{Content: []any{"other.net."}}}},
+	}})
+	require.Len(t, findings, 1)
+	assert.Equal(t, LintCNAMEApex, findings[0].Code)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+}
+
+func TestLinter_customRule(t *testing.T) {
+	lowTTL := LintRule{
+		Code:     "ACME001",
+		Severity: SeverityWarning,
+		Check: func(zone *LintZone) []Finding {
+			var res []Finding
+			for _, set := range zone.RRSets {
+				if set.TTL < 60 {
+					res = append(res, Finding{Name: set.Name, Type: set.Type, Message: "ttl is too low"})
+				}
+			}
+			return res
+		},
+	}
+	zone := Snapshot{Zone: "example.com", RRSets: []SnapshotRRSet{
+		{Name: "www.example.com", Type: "A", TTL: 10, Records: []ResourceRecord{{Content: []any{"192.0.2.1"}}}},
+	}}
+
+	findings := NewLinter(lowTTL).Lint(zone)
+	assert.Equal(t, []Finding{{Code: "ACME001", Severity: SeverityWarning, Name: "www.example.com", Type: "A",
+		Message: "ttl is too low"}}, findings)
+
+	linter := NewLinter().Add(lowTTL)
+	assert.Len(t, linter.Rules(), len(DefaultLintRules())+1)
+	assert.Len(t, linter.Lint(zone), 1)
+}
@@ -0,0 +1,833 @@
+package dnssdk
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// errors wrapped by parsers and validators of email authentication records
+var (
+	ErrInvalidSPF   = errors.New("invalid spf")
+	ErrInvalidDKIM  = errors.New("invalid dkim")
+	ErrInvalidDMARC = errors.New("invalid dmarc")
+)
+
+// ErrSPFFlatten included record can not be replaced by its mechanisms without changing the result
+var ErrSPFFlatten = errors.New("spf can not be flattened")
+
+// SPFQualifier of SPF mechanism, empty is the same as SPFPass
+type SPFQualifier string
+
+// SPF qualifiers
+const (
+	SPFPass     SPFQualifier = "+"
+	SPFFail     SPFQualifier = "-"
+	SPFSoftFail SPFQualifier = "~"
+	SPFNeutral  SPFQualifier = "?"
+)
+
+// SPF mechanisms
+const (
+	SPFAll     = "all"
+	SPFInclude = "include"
+	SPFA       = "a"
+	SPFMX      = "mx"
+	SPFPTR     = "ptr"
+	SPFIP4     = "ip4"
+	SPFIP6     = "ip6"
+	SPFExists  = "exists"
+)
+
+// SPFMechanism term of SPF record like -ip4:192.0.2.0/24 or mx:example.com/24
+type SPFMechanism struct {
+	Qualifier SPFQualifier
+	Kind      string
+	// Value is domain, or address with prefix for ip4 and ip6
+	Value string
+	// Prefix is dual cidr length of a and mx, e.g. /24//64
+	Prefix string
+}
+
+// String implementation
+func (m SPFMechanism) String() string {
+	s := string(m.Qualifier) + m.Kind
+	if m.Value != "" {
+		s += ":" + m.Value
+	}
+	return s + m.Prefix
+}
+
+func (m SPFMechanism) pass() bool {
+	return m.Qualifier == "" || m.Qualifier == SPFPass
+}
+
+// SPF record, build with NewSPF or parse with ParseSPF
+type SPF struct {
+	Mechanisms []SPFMechanism
+	Redirect   string
+	Exp        string
+	// Modifiers unknown to SDK, kept as is
+	Modifiers []string
+}
+
+// NewSPF builder
+func NewSPF() *SPF {
+	return &SPF{}
+}
+
+// Add mechanism to SPF
+func (s *SPF) Add(m SPFMechanism) *SPF {
+	s.Mechanisms = append(s.Mechanisms, m)
+	return s
+}
+
+// AddInclude mechanism to SPF
+func (s *SPF) AddInclude(domain string) *SPF {
+	return s.Add(SPFMechanism{Kind: SPFInclude, Value: domain})
+}
+
+// AddIP4 mechanism to SPF, address or cidr
+func (s *SPF) AddIP4(addr string) *SPF {
+	return s.Add(SPFMechanism{Kind: SPFIP4, Value: addr})
+}
+
+// AddIP6 mechanism to SPF, address or cidr
+func (s *SPF) AddIP6(addr string) *SPF {
+	return s.Add(SPFMechanism{Kind: SPFIP6, Value: addr})
+}
+
+// AddA mechanism to SPF, empty domain is domain of record
+func (s *SPF) AddA(domain string) *SPF {
+	return s.Add(SPFMechanism{Kind: SPFA, Value: domain})
+}
+
+// AddMX mechanism to SPF, empty domain is domain of record
+func (s *SPF) AddMX(domain string) *SPF {
+	return s.Add(SPFMechanism{Kind: SPFMX, Value: domain})
+}
+
+// AddExists mechanism to SPF
+func (s *SPF) AddExists(domain string) *SPF {
+	return s.Add(SPFMechanism{Kind: SPFExists, Value: domain})
+}
+
+// SetAll sets the last all mechanism with qualifier
+func (s *SPF) SetAll(q SPFQualifier) *SPF {
+	for i, m := range s.Mechanisms {
+		if m.Kind == SPFAll {
+			s.Mechanisms = append(s.Mechanisms[:i], s.Mechanisms[i+1:]...)
+			break
+		}
+	}
+	return s.Add(SPFMechanism{Qualifier: q, Kind: SPFAll})
+}
+
+// SetRedirect modifier of SPF
+func (s *SPF) SetRedirect(domain string) *SPF {
+	s.Redirect = domain
+	return s
+}
+
+// String as TXT value: v=spf1 ...
+func (s SPF) String() string {
+	parts := []string{spfPrefix}
+	for _, m := range s.Mechanisms {
+		parts = append(parts, m.String())
+	}
+	if s.Redirect != "" {
+		parts = append(parts, "redirect="+s.Redirect)
+	}
+	if s.Exp != "" {
+		parts = append(parts, "exp="+s.Exp)
+	}
+	parts = append(parts, s.Modifiers...)
+	return strings.Join(parts, " ")
+}
+
+// Record of TXT RRSet
+func (s SPF) Record() ResourceRecord {
+	return ResourceRecord{Content: ContentFromValue("TXT", s.String()), Enabled: true}
+}
+
+// Lookups DNS lookups of record itself, includes are not followed
+func (s SPF) Lookups() int {
+	return spfDirectLookups(s.String())
+}
+
+// Validate SPF: known mechanisms, valid addresses, nothing after all, lookups limit
+func (s SPF) Validate() error {
+	for i, m := range s.Mechanisms {
+		if err := m.validate(); err != nil {
+			return err
+		}
+		if m.Kind == SPFAll && i != len(s.Mechanisms)-1 {
+			return fmt.Errorf("%w: mechanisms after all are never evaluated", ErrInvalidSPF)
+		}
+	}
+	if n := s.Lookups(); n > spfLookupsLimit {
+		return fmt.Errorf("%w: %d lookups, limit is %d", ErrInvalidSPF, n, spfLookupsLimit)
+	}
+	return nil
+}
+
+func (m SPFMechanism) validate() error {
+	switch m.Qualifier {
+	case "", SPFPass, SPFFail, SPFSoftFail, SPFNeutral:
+	default:
+		return fmt.Errorf("%w: qualifier %q", ErrInvalidSPF, m.Qualifier)
+	}
+	switch m.Kind {
+	case SPFAll:
+		if m.Value != "" || m.Prefix != "" {
+			return fmt.Errorf("%w: all has no value", ErrInvalidSPF)
+		}
+	case SPFIP4, SPFIP6:
+		ip := net.ParseIP(m.Value)
+		if ip == nil {
+			var err error
+			if ip, _, err = net.ParseCIDR(m.Value); err != nil {
+				return fmt.Errorf("%w: %s: bad address", ErrInvalidSPF, m)
+			}
+		}
+		if (m.Kind == SPFIP4) != (ip.To4() != nil) {
+			return fmt.Errorf("%w: %s: address family mismatch", ErrInvalidSPF, m)
+		}
+	case SPFInclude, SPFExists:
+		if m.Value == "" {
+			return fmt.Errorf("%w: %s requires domain", ErrInvalidSPF, m.Kind)
+		}
+	case SPFA, SPFMX, SPFPTR:
+	default:
+		return fmt.Errorf("%w: unknown mechanism %s", ErrInvalidSPF, m.Kind)
+	}
+	return nil
+}
+
+// ParseSPF from TXT value, e.g. ContentToString of record, quoted presentation is accepted
+func ParseSPF(value string) (*SPF, error) {
+	fields := strings.Fields(txtContent(value))
+	if len(fields) == 0 || !strings.EqualFold(fields[0], spfPrefix) {
+		return nil, fmt.Errorf("%w: no %s prefix", ErrInvalidSPF, spfPrefix)
+	}
+	s := &SPF{}
+	for _, term := range fields[1:] {
+		if name, v, ok := strings.Cut(term, "="); ok && !strings.ContainsAny(name, ":/") {
+			switch strings.ToLower(name) {
+			case "redirect":
+				if s.Redirect != "" {
+					return nil, fmt.Errorf("%w: duplicate redirect", ErrInvalidSPF)
+				}
+				s.Redirect = v
+			case "exp":
+				if s.Exp != "" {
+					return nil, fmt.Errorf("%w: duplicate exp", ErrInvalidSPF)
+				}
+				s.Exp = v
+			default:
+				s.Modifiers = append(s.Modifiers, term)
+			}
+			continue
+		}
+		m := SPFMechanism{}
+		if strings.ContainsAny(term[:1], "+-~?") {
+			m.Qualifier, term = SPFQualifier(term[:1]), term[1:]
+		}
+		m.Kind = term
+		if i := strings.IndexAny(term, ":/"); i >= 0 {
+			m.Kind, term = term[:i], term[i:]
+			if strings.HasPrefix(term, ":") {
+				m.Value, term = term[1:], ""
+			}
+			if m.Kind != SPFIP4 && m.Kind != SPFIP6 {
+				if j := strings.IndexByte(m.Value, '/'); j >= 0 {
+					m.Value, term = m.Value[:j], m.Value[j:]
+				}
+				m.Prefix = term
+			} else if term != "" {
+				return nil, fmt.Errorf("%w: %s requires address", ErrInvalidSPF, m.Kind)
+			}
+		}
+		m.Kind = strings.ToLower(m.Kind)
+		if err := m.validate(); err != nil {
+			return nil, err
+		}
+		s.Mechanisms = append(s.Mechanisms, m)
+	}
+	return s, nil
+}
+
+// SPFResolver of TXT records for SPF flattening, *net.Resolver implements it
+type SPFResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// SPFResolverFunc adapter of func to SPFResolver
+type SPFResolverFunc func(ctx context.Context, name string) ([]string, error)
+
+// LookupTXT implementation
+func (f SPFResolverFunc) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return f(ctx, name)
+}
+
+// SPFFlattenResult flattened SPF and lookups counters
+type SPFFlattenResult struct {
+	SPF *SPF
+	// Lookups DNS lookups needed to evaluate original record with all includes
+	Lookups int
+	// Remaining DNS lookups needed to evaluate flattened record
+	Remaining int
+}
+
+// Flatten replaces includes and redirect with mechanisms of resolved records.
+// Inlined mechanisms keep qualifier of include, a and mx get domain of included record and still need lookups.
+// Included records with not passing mechanisms other than all or with passing all are not flattened,
+// error wraps ErrSPFFlatten.
+func (s SPF) Flatten(ctx context.Context, resolver SPFResolver) (SPFFlattenResult, error) {
+	f := &spfFlattener{resolver: resolver, path: map[string]bool{}, seen: map[string]bool{}}
+	out := &SPF{Exp: s.Exp, Modifiers: s.Modifiers}
+	hasAll := false
+	for _, m := range s.Mechanisms {
+		if m.Kind == SPFInclude {
+			mechs, err := f.expand(ctx, m.Value, false)
+			if err != nil {
+				return SPFFlattenResult{}, err
+			}
+			out.Mechanisms = f.appendUnique(out.Mechanisms, withQualifier(mechs, m.Qualifier)...)
+			continue
+		}
+		f.count(m)
+		hasAll = hasAll || m.Kind == SPFAll
+		out.Mechanisms = f.appendUnique(out.Mechanisms, m)
+	}
+	if s.Redirect != "" && !hasAll {
+		mechs, err := f.expand(ctx, s.Redirect, true)
+		if err != nil {
+			return SPFFlattenResult{}, err
+		}
+		out.Mechanisms = f.appendUnique(out.Mechanisms, mechs...)
+	}
+	return SPFFlattenResult{SPF: out, Lookups: f.lookups, Remaining: out.Lookups()}, nil
+}
+
+type spfFlattener struct {
+	resolver SPFResolver
+	lookups  int
+	path     map[string]bool
+	seen     map[string]bool
+}
+
+func (f *spfFlattener) count(m SPFMechanism) {
+	switch m.Kind {
+	case SPFInclude, SPFA, SPFMX, SPFPTR, SPFExists:
+		f.lookups++
+	}
+}
+
+func (f *spfFlattener) appendUnique(res []SPFMechanism, mechs ...SPFMechanism) []SPFMechanism {
+	for _, m := range mechs {
+		key := m.String()
+		if m.pass() {
+			key = SPFMechanism{Kind: m.Kind, Value: m.Value, Prefix: m.Prefix}.String()
+		}
+		if !f.seen[key] {
+			f.seen[key] = true
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+func (f *spfFlattener) expand(ctx context.Context, domain string, keepAll bool) ([]SPFMechanism, error) {
+	f.lookups++
+	key := normalizeName(domain)
+	if f.path[key] {
+		return nil, fmt.Errorf("%w: include loop at %s", ErrInvalidSPF, domain)
+	}
+	f.path[key] = true
+	defer delete(f.path, key)
+
+	txts, err := f.resolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("lookup spf of %s: %w", domain, err)
+	}
+	var record *SPF
+	for _, txt := range txts {
+		if DetectTXT(domain, txt) != TXTSPF {
+			continue
+		}
+		if record != nil {
+			return nil, fmt.Errorf("%w: %s has more than one spf record", ErrInvalidSPF, domain)
+		}
+		if record, err = ParseSPF(txt); err != nil {
+			return nil, fmt.Errorf("%s: %w", domain, err)
+		}
+	}
+	if record == nil {
+		return nil, fmt.Errorf("%w: %s has no spf record", ErrInvalidSPF, domain)
+	}
+
+	var res []SPFMechanism
+	hasAll := false
+	for _, m := range record.Mechanisms {
+		if m.Kind == SPFAll {
+			hasAll = true
+			if !keepAll && m.pass() {
+				// include matching everything ends evaluation, inlined all would be followed by other mechanisms
+				return nil, fmt.Errorf("%w: include %s has %s", ErrSPFFlatten, domain, m)
+			}
+			// not passing all of included record only makes include not match
+			if keepAll {
+				res = append(res, m)
+			}
+			break
+		}
+		switch {
+		case !m.pass() && !keepAll:
+			// matched not passing term stops evaluation of included record, dropping it widens include
+			return nil, fmt.Errorf("%w: include %s has %s", ErrSPFFlatten, domain, m)
+		case m.Kind == SPFInclude:
+			mechs, err := f.expand(ctx, m.Value, false)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, withQualifier(mechs, m.Qualifier)...)
+		default:
+			f.count(m)
+			if (m.Kind == SPFA || m.Kind == SPFMX || m.Kind == SPFPTR) && m.Value == "" {
+				m.Value = domain
+			}
+			res = append(res, m)
+		}
+	}
+	if record.Redirect != "" && !hasAll {
+		mechs, err := f.expand(ctx, record.Redirect, keepAll)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, mechs...)
+	}
+	return res, nil
+}
+
+// withQualifier of include for mechanisms of included record which are all passing
+func withQualifier(mechs []SPFMechanism, q SPFQualifier) []SPFMechanism {
+	if q == "" || q == SPFPass {
+		return mechs
+	}
+	res := make([]SPFMechanism, len(mechs))
+	for i, m := range mechs {
+		m.Qualifier = q
+		res[i] = m
+	}
+	return res
+}
+
+// DKIM key types
+const (
+	DKIMKeyRSA     = "rsa"
+	DKIMKeyEd25519 = "ed25519"
+)
+
+// DKIMMinRSAKeyBits min size of RSA key accepted by verifiers, 2048 is recommended
+const DKIMMinRSAKeyBits = 1024
+
+const dkimVersion = "DKIM1"
+
+// DKIM key record published at selector._domainkey.domain
+type DKIM struct {
+	// KeyType k= tag, rsa when empty
+	KeyType string
+	// PublicKey p= tag, DER of SubjectPublicKeyInfo for rsa and raw key for ed25519, empty means revoked
+	PublicKey      []byte
+	HashAlgorithms []string
+	ServiceTypes   []string
+	Flags          []string
+	Notes          string
+}
+
+// DKIMName owner name of DKIM key record
+func DKIMName(selector, domain string) string {
+	return selector + "._domainkey." + strings.TrimSuffix(domain, ".")
+}
+
+// NewDKIM from *rsa.PublicKey or ed25519.PublicKey
+func NewDKIM(pub crypto.PublicKey) (*DKIM, error) {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("marshal rsa key: %w", err)
+		}
+		return &DKIM{KeyType: DKIMKeyRSA, PublicKey: der}, nil
+	case ed25519.PublicKey:
+		return &DKIM{KeyType: DKIMKeyEd25519, PublicKey: append([]byte{}, key...)}, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported key %T", ErrInvalidDKIM, pub)
+}
+
+// SetTesting sets t=y flag, verifiers treat signatures as unsigned
+func (d *DKIM) SetTesting() *DKIM {
+	d.Flags = append(d.Flags, "y")
+	return d
+}
+
+// SetNotes n= tag of DKIM
+func (d *DKIM) SetNotes(notes string) *DKIM {
+	d.Notes = notes
+	return d
+}
+
+// Revoked is true for empty public key
+func (d DKIM) Revoked() bool {
+	return len(d.PublicKey) == 0
+}
+
+// KeySize in bits
+func (d DKIM) KeySize() (int, error) {
+	if d.Revoked() {
+		return 0, fmt.Errorf("%w: key is revoked", ErrInvalidDKIM)
+	}
+	switch strings.ToLower(d.KeyType) {
+	case "", DKIMKeyRSA:
+		if pub, err := x509.ParsePKIXPublicKey(d.PublicKey); err == nil {
+			if key, ok := pub.(*rsa.PublicKey); ok {
+				return key.N.BitLen(), nil
+			}
+			return 0, fmt.Errorf("%w: key is %T, not rsa", ErrInvalidDKIM, pub)
+		}
+		key, err := x509.ParsePKCS1PublicKey(d.PublicKey)
+		if err != nil {
+			return 0, fmt.Errorf("%w: parse rsa key: %v", ErrInvalidDKIM, err)
+		}
+		return key.N.BitLen(), nil
+	case DKIMKeyEd25519:
+		if len(d.PublicKey) != ed25519.PublicKeySize {
+			return 0, fmt.Errorf("%w: ed25519 key of %d bytes", ErrInvalidDKIM, len(d.PublicKey))
+		}
+		// nolint: gomnd
+		return ed25519.PublicKeySize * 8, nil
+	}
+	return 0, fmt.Errorf("%w: unknown key type %s", ErrInvalidDKIM, d.KeyType)
+}
+
+// Validate DKIM: key parses and rsa key is not shorter than DKIMMinRSAKeyBits, revoked key is valid
+func (d DKIM) Validate() error {
+	if d.Revoked() {
+		return nil
+	}
+	size, err := d.KeySize()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(d.KeyType, DKIMKeyEd25519) && size < DKIMMinRSAKeyBits {
+		return fmt.Errorf("%w: rsa key of %d bits, min is %d", ErrInvalidDKIM, size, DKIMMinRSAKeyBits)
+	}
+	return nil
+}
+
+// String as TXT value: v=DKIM1; k=rsa; p=...
+func (d DKIM) String() string {
+	keyType := d.KeyType
+	if keyType == "" {
+		keyType = DKIMKeyRSA
+	}
+	tags := []string{"v=" + dkimVersion, "k=" + keyType}
+	if len(d.HashAlgorithms) > 0 {
+		tags = append(tags, "h="+strings.Join(d.HashAlgorithms, ":"))
+	}
+	if len(d.ServiceTypes) > 0 {
+		tags = append(tags, "s="+strings.Join(d.ServiceTypes, ":"))
+	}
+	if len(d.Flags) > 0 {
+		tags = append(tags, "t="+strings.Join(d.Flags, ":"))
+	}
+	if d.Notes != "" {
+		tags = append(tags, "n="+d.Notes)
+	}
+	tags = append(tags, "p="+base64.StdEncoding.EncodeToString(d.PublicKey))
+	return strings.Join(tags, "; ")
+}
+
+// Record of TXT RRSet with value as single content, QuoteTXT splits long keys for zone file
+func (d DKIM) Record() ResourceRecord {
+	return ResourceRecord{Content: ContentFromValue("TXT", d.String()), Enabled: true}
+}
+
+// ParseDKIM from TXT value, quoted presentation is accepted
+func ParseDKIM(value string) (*DKIM, error) {
+	tags, err := parseTagList(txtContent(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDKIM, err)
+	}
+	if v, ok := tags.get("v"); ok && (v != dkimVersion || tags[0].name != "v") {
+		return nil, fmt.Errorf("%w: version %s", ErrInvalidDKIM, v)
+	}
+	p, ok := tags.get("p")
+	if !ok {
+		return nil, fmt.Errorf("%w: p= is required", ErrInvalidDKIM)
+	}
+	d := &DKIM{}
+	d.PublicKey, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(p), ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: p=: %v", ErrInvalidDKIM, err)
+	}
+	if d.KeyType, ok = tags.get("k"); !ok {
+		d.KeyType = DKIMKeyRSA
+	}
+	d.KeyType = strings.ToLower(d.KeyType)
+	d.HashAlgorithms = tags.list("h", ":")
+	d.ServiceTypes = tags.list("s", ":")
+	d.Flags = tags.list("t", ":")
+	d.Notes, _ = tags.get("n")
+	return d, nil
+}
+
+// DMARCPolicy requested for failed messages
+type DMARCPolicy string
+
+// DMARC policies
+const (
+	DMARCNone       DMARCPolicy = "none"
+	DMARCQuarantine DMARCPolicy = "quarantine"
+	DMARCReject     DMARCPolicy = "reject"
+)
+
+// DMARCAlignment mode of adkim and aspf
+type DMARCAlignment string
+
+// DMARC alignment modes
+const (
+	DMARCRelaxed DMARCAlignment = "r"
+	DMARCStrict  DMARCAlignment = "s"
+)
+
+const dmarcVersion = "DMARC1"
+
+// DMARC policy record published at _dmarc.domain
+type DMARC struct {
+	Policy          DMARCPolicy
+	SubdomainPolicy DMARCPolicy
+	// Percent pct= tag, nil is 100
+	Percent *int
+	// RUA aggregate report URIs, e.g. mailto:dmarc@example.com
+	RUA []string
+	// RUF failure report URIs
+	RUF            []string
+	DKIMAlignment  DMARCAlignment
+	SPFAlignment   DMARCAlignment
+	FailureOptions string
+	ReportInterval uint32
+}
+
+// DMARCName owner name of DMARC record
+func DMARCName(domain string) string {
+	return "_dmarc." + strings.TrimSuffix(domain, ".")
+}
+
+// NewDMARC builder
+func NewDMARC(policy DMARCPolicy) *DMARC {
+	return &DMARC{Policy: policy}
+}
+
+// SetSubdomainPolicy sp= tag of DMARC
+func (d *DMARC) SetSubdomainPolicy(policy DMARCPolicy) *DMARC {
+	d.SubdomainPolicy = policy
+	return d
+}
+
+// SetPercent pct= tag of DMARC
+func (d *DMARC) SetPercent(pct int) *DMARC {
+	d.Percent = &pct
+	return d
+}
+
+// AddRUA aggregate report URIs
+func (d *DMARC) AddRUA(uris ...string) *DMARC {
+	d.RUA = append(d.RUA, uris...)
+	return d
+}
+
+// AddRUF failure report URIs
+func (d *DMARC) AddRUF(uris ...string) *DMARC {
+	d.RUF = append(d.RUF, uris...)
+	return d
+}
+
+// SetAlignment adkim= and aspf= tags of DMARC
+func (d *DMARC) SetAlignment(dkim, spf DMARCAlignment) *DMARC {
+	d.DKIMAlignment, d.SPFAlignment = dkim, spf
+	return d
+}
+
+// Validate DMARC: policies, pct range, alignment modes and report URIs
+func (d DMARC) Validate() error {
+	if !validDMARCPolicy(d.Policy) {
+		return fmt.Errorf("%w: policy %q", ErrInvalidDMARC, d.Policy)
+	}
+	if d.SubdomainPolicy != "" && !validDMARCPolicy(d.SubdomainPolicy) {
+		return fmt.Errorf("%w: subdomain policy %q", ErrInvalidDMARC, d.SubdomainPolicy)
+	}
+	// nolint: gomnd
+	if d.Percent != nil && (*d.Percent < 0 || *d.Percent > 100) {
+		return fmt.Errorf("%w: pct %d out of 0-100", ErrInvalidDMARC, *d.Percent)
+	}
+	for _, a := range []DMARCAlignment{d.DKIMAlignment, d.SPFAlignment} {
+		if a != "" && a != DMARCRelaxed && a != DMARCStrict {
+			return fmt.Errorf("%w: alignment %q", ErrInvalidDMARC, a)
+		}
+	}
+	for _, uri := range append(append([]string{}, d.RUA...), d.RUF...) {
+		address := strings.SplitN(uri, "!", 2)[0]
+		scheme, rest, ok := strings.Cut(address, ":")
+		if !ok || rest == "" || (strings.EqualFold(scheme, "mailto") && !strings.Contains(rest, "@")) {
+			return fmt.Errorf("%w: report uri %q", ErrInvalidDMARC, uri)
+		}
+	}
+	return nil
+}
+
+func validDMARCPolicy(p DMARCPolicy) bool {
+	return p == DMARCNone || p == DMARCQuarantine || p == DMARCReject
+}
+
+// String as TXT value: v=DMARC1; p=reject; ...
+func (d DMARC) String() string {
+	tags := []string{"v=" + dmarcVersion, "p=" + string(d.Policy)}
+	if d.SubdomainPolicy != "" {
+		tags = append(tags, "sp="+string(d.SubdomainPolicy))
+	}
+	if d.Percent != nil {
+		tags = append(tags, "pct="+strconv.Itoa(*d.Percent))
+	}
+	if len(d.RUA) > 0 {
+		tags = append(tags, "rua="+strings.Join(d.RUA, ","))
+	}
+	if len(d.RUF) > 0 {
+		tags = append(tags, "ruf="+strings.Join(d.RUF, ","))
+	}
+	if d.DKIMAlignment != "" {
+		tags = append(tags, "adkim="+string(d.DKIMAlignment))
+	}
+	if d.SPFAlignment != "" {
+		tags = append(tags, "aspf="+string(d.SPFAlignment))
+	}
+	if d.FailureOptions != "" {
+		tags = append(tags, "fo="+d.FailureOptions)
+	}
+	if d.ReportInterval != 0 {
+		tags = append(tags, "ri="+strconv.FormatUint(uint64(d.ReportInterval), 10))
+	}
+	return strings.Join(tags, "; ")
+}
+
+// Record of TXT RRSet
+func (d DMARC) Record() ResourceRecord {
+	return ResourceRecord{Content: ContentFromValue("TXT", d.String()), Enabled: true}
+}
+
+// ParseDMARC from TXT value, quoted presentation is accepted, unknown tags are ignored
+func ParseDMARC(value string) (*DMARC, error) {
+	tags, err := parseTagList(txtContent(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDMARC, err)
+	}
+	if len(tags) == 0 || tags[0].name != "v" || tags[0].value != dmarcVersion {
+		return nil, fmt.Errorf("%w: v=%s must be the first tag", ErrInvalidDMARC, dmarcVersion)
+	}
+	d := &DMARC{}
+	p, _ := tags.get("p")
+	sp, _ := tags.get("sp")
+	d.Policy, d.SubdomainPolicy = DMARCPolicy(strings.ToLower(p)), DMARCPolicy(strings.ToLower(sp))
+	if pct, ok := tags.get("pct"); ok {
+		n, err := strconv.Atoi(pct)
+		if err != nil {
+			return nil, fmt.Errorf("%w: pct=%s", ErrInvalidDMARC, pct)
+		}
+		d.Percent = &n
+	}
+	d.RUA = tags.list("rua", ",")
+	d.RUF = tags.list("ruf", ",")
+	adkim, _ := tags.get("adkim")
+	aspf, _ := tags.get("aspf")
+	d.DKIMAlignment, d.SPFAlignment = DMARCAlignment(strings.ToLower(adkim)), DMARCAlignment(strings.ToLower(aspf))
+	d.FailureOptions, _ = tags.get("fo")
+	if ri, ok := tags.get("ri"); ok {
+		n, err := strconv.ParseUint(ri, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ri=%s", ErrInvalidDMARC, ri)
+		}
+		d.ReportInterval = uint32(n)
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+type tag struct {
+	name, value string
+}
+
+// tagList of DKIM and DMARC records: name=value pairs separated by ;
+type tagList []tag
+
+func parseTagList(value string) (tagList, error) {
+	var res tagList
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, v, ok := strings.Cut(part, "=")
+		if !ok {
+			// nolint: goerr113
+			return nil, fmt.Errorf("tag %q has no value", part)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			// nolint: goerr113
+			return nil, fmt.Errorf("duplicate tag %s", name)
+		}
+		seen[name] = true
+		res = append(res, tag{name: name, value: strings.TrimSpace(v)})
+	}
+	return res, nil
+}
+
+func (l tagList) get(name string) (string, bool) {
+	for _, t := range l {
+		if t.name == name {
+			return t.value, true
+		}
+	}
+	return "", false
+}
+
+func (l tagList) list(name, sep string) []string {
+	v, ok := l.get(name)
+	if !ok || v == "" {
+		return nil
+	}
+	var res []string
+	for _, item := range strings.Split(v, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// txtContent joined value of TXT content or quoted presentation
+func txtContent(value string) string {
+	return RecordTypeTXT(value).ToContent()[0].(string)
+}
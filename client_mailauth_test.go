@@ -0,0 +1,206 @@
+package dnssdk
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPF_build(t *testing.T) {
+	spf := NewSPF().AddIP4("192.0.2.0/24").AddIP6("2001:db8::/32").AddMX("").
+		AddInclude("_spf.example.net").SetAll(SPFSoftFail).SetAll(SPFFail)
+
+	assert.Equal(t, "v=spf1 ip4:192.0.2.0/24 ip6:2001:db8::/32 mx include:_spf.example.net -all", spf.String())
+	assert.Equal(t, 2, spf.Lookups())
+	require.NoError(t, spf.Validate())
+
+	record := spf.Record()
+	parsed, err := ParseSPF(record.ContentToString())
+	require.NoError(t, err)
+	assert.Equal(t, spf, parsed)
+
+	assert.ErrorIs(t, NewSPF().AddIP4("2001:db8::1").Validate(), ErrInvalidSPF)
+	assert.ErrorIs(t, NewSPF().SetAll(SPFFail).AddMX("").Validate(), ErrInvalidSPF)
+	tooMany := NewSPF()
+	for i := 0; i < 11; i++ {
+		tooMany.AddA("")
+	}
+	assert.ErrorIs(t, tooMany.Validate(), ErrInvalidSPF)
+}
+
+func TestParseSPF(t *testing.T) {
+	spf, err := ParseSPF(`"v=spf1 +a/24 ~mx:mail.example.com/24//64 ?exists:%{i}.x.example.com " "redirect=other.example.com exp=exp.example.com x-foo=bar"`)
+	require.NoError(t, err)
+	assert.Equal(t, []SPFMechanism{
+		{Qualifier: SPFPass, Kind: SPFA, Prefix: "/24"},
+		{Qualifier: SPFSoftFail, Kind: SPFMX, Value: "mail.example.com", Prefix: "/24//64"},
+		{Qualifier: SPFNeutral, Kind: SPFExists, Value: "%{i}.x.example.com"},
+	}, spf.Mechanisms)
+	assert.Equal(t, "other.example.com", spf.Redirect)
+	assert.Equal(t, "exp.example.com", spf.Exp)
+	assert.Equal(t, []string{"x-foo=bar"}, spf.Modifiers)
+	assert.Equal(t, "v=spf1 +a/24 ~mx:mail.example.com/24//64 ?exists:%{i}.x.example.com "+
+		"redirect=other.example.com exp=exp.example.com x-foo=bar", spf.String())
+
+	for _, bad := range []string{"v=spf2 -all", "v=spf1 foo:bar", "v=spf1 ip4:300.1.1.1", "v=spf1 include",
+		"v=spf1 redirect=a redirect=b", "v=spf1 ip4/24"} {
+		_, err := ParseSPF(bad)
+		assert.ErrorIs(t, err, ErrInvalidSPF, bad)
+	}
+}
+
+func TestSPF_Flatten(t *testing.T) {
+	records := map[string][]string{
+		"_spf.example.net":  {"v=spf1 ip4:198.51.100.0/24 include:_spf2.example.net ~all", "other"},
+		"deny.example.net":  {"v=spf1 -ip4:198.51.100.7 ip4:198.51.100.0/24 -all"},
+		"soft.example.net":  {"v=spf1 ip4:203.0.113.0/24 ~include:_spf2.example.net -all"},
+		"_spf2.example.net": {"v=spf1 a ip6:2001:db8:1::/48 ip4:198.51.100.0/24"},
+		"base.example.org":  {"v=spf1 mx -all"},
+		"loop.example.org":  {"v=spf1 include:loop.example.org"},
+		"open.example.net":  {"v=spf1 ip4:198.51.100.0/24 +all"},
+		"redir.example.net": {"v=spf1 redirect=open.example.net"},
+	}
+	calls := 0
+	resolver := SPFResolverFunc(func(_ context.Context, name string) ([]string, error) {
+		calls++
+		txts, ok := records[name]
+		if !ok {
+			return nil, errors.New("nxdomain")
+		}
+		return txts, nil
+	})
+
+	spf, err := ParseSPF("v=spf1 ip4:192.0.2.1 include:_spf.example.net redirect=base.example.org")
+	require.NoError(t, err)
+	res, err := spf.Flatten(context.Background(), resolver)
+	require.NoError(t, err)
+	assert.Equal(t, "v=spf1 ip4:192.0.2.1 ip4:198.51.100.0/24 a:_spf2.example.net ip6:2001:db8:1::/48 "+
+		"mx:base.example.org -all", res.SPF.String())
+	require.NoError(t, res.SPF.Validate())
+	assert.Equal(t, 5, res.Lookups, "2 includes, a, redirect and mx")
+	assert.Equal(t, 2, res.Remaining)
+	assert.Equal(t, 3, calls)
+
+	loop, err := ParseSPF("v=spf1 include:loop.example.org -all")
+	require.NoError(t, err)
+	_, err = loop.Flatten(context.Background(), resolver)
+	assert.ErrorIs(t, err, ErrInvalidSPF)
+
+	// dropping -ip4 of included record would make include match it
+	deny, err := ParseSPF("v=spf1 include:deny.example.net -all")
+	require.NoError(t, err)
+	_, err = deny.Flatten(context.Background(), resolver)
+	assert.ErrorIs(t, err, ErrSPFFlatten)
+	assert.ErrorContains(t, err, "include deny.example.net has -ip4:198.51.100.7")
+
+	nested, err := ParseSPF("v=spf1 include:soft.example.net -all")
+	require.NoError(t, err)
+	_, err = nested.Flatten(context.Background(), resolver)
+	assert.ErrorIs(t, err, ErrSPFFlatten, "not passing include in included record")
+
+	// qualifiers are kept on inlined mechanisms of redirect and not passing includes
+	redirect, err := ParseSPF("v=spf1 ?include:_spf2.example.net redirect=soft.example.net")
+	require.NoError(t, err)
+	res, err = redirect.Flatten(context.Background(), resolver)
+	require.NoError(t, err)
+	require.NoError(t, res.SPF.Validate())
+	assert.Equal(t, "v=spf1 ?a:_spf2.example.net ?ip6:2001:db8:1::/48 ?ip4:198.51.100.0/24 ip4:203.0.113.0/24 "+
+		"~a:_spf2.example.net ~ip6:2001:db8:1::/48 ~ip4:198.51.100.0/24 -all", res.SPF.String())
+
+	// passing all of included record would be inlined before mechanisms following include
+	for _, value := range []string{
+		"v=spf1 include:open.example.net ip4:192.0.2.1 -all",
+		"v=spf1 include:redir.example.net -all",
+	} {
+		open, err := ParseSPF(value)
+		require.NoError(t, err)
+		_, err = open.Flatten(context.Background(), resolver)
+		assert.ErrorIs(t, err, ErrSPFFlatten, value)
+		assert.ErrorContains(t, err, "has +all", value)
+	}
+
+	missing, err := ParseSPF("v=spf1 include:missing.example.org -all")
+	require.NoError(t, err)
+	_, err = missing.Flatten(context.Background(), resolver)
+	assert.ErrorContains(t, err, "nxdomain")
+}
+
+func TestDKIM(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	dkim, err := NewDKIM(&key.PublicKey)
+	require.NoError(t, err)
+	dkim.SetNotes("rotated").SetTesting()
+	require.NoError(t, dkim.Validate())
+	size, err := dkim.KeySize()
+	require.NoError(t, err)
+	assert.Equal(t, 2048, size)
+	assert.True(t, strings.HasPrefix(dkim.String(), "v=DKIM1; k=rsa; t=y; n=rotated; p=MIIB"))
+	assert.Equal(t, "sel._domainkey.example.com", DKIMName("sel", "example.com."))
+
+	zoneValue := QuoteTXT(dkim.Record().ContentToString())
+	parsed, err := ParseDKIM(zoneValue)
+	require.NoError(t, err)
+	assert.Equal(t, dkim, parsed)
+
+	weak, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+	dkim, err = NewDKIM(&weak.PublicKey)
+	require.NoError(t, err)
+	assert.ErrorIs(t, dkim.Validate(), ErrInvalidDKIM)
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	dkim, err = NewDKIM(pub)
+	require.NoError(t, err)
+	require.NoError(t, dkim.Validate())
+	parsed, err = ParseDKIM(dkim.String())
+	require.NoError(t, err)
+	assert.Equal(t, DKIMKeyEd25519, parsed.KeyType)
+	assert.Equal(t, []byte(pub), parsed.PublicKey)
+	dkim.KeyType = "Ed25519"
+	assert.NoError(t, dkim.Validate(), "key type is case insensitive")
+
+	revoked, err := ParseDKIM("v=DKIM1; p=")
+	require.NoError(t, err)
+	assert.True(t, revoked.Revoked())
+	assert.NoError(t, revoked.Validate())
+
+	for _, bad := range []string{"k=rsa", "v=DKIM2; p=", "k=rsa; v=DKIM1; p=", "p=!!", "p=; p="} {
+		_, err := ParseDKIM(bad)
+		assert.ErrorIs(t, err, ErrInvalidDKIM, bad)
+	}
+	_, err = (&DKIM{KeyType: "rsa", PublicKey: []byte("junk")}).KeySize()
+	assert.ErrorIs(t, err, ErrInvalidDKIM)
+}
+
+func TestDMARC(t *testing.T) {
+	dmarc := NewDMARC(DMARCQuarantine).SetSubdomainPolicy(DMARCReject).SetPercent(0).
+		AddRUA("mailto:agg@example.com", "mailto:agg@example.net!10m").AddRUF("mailto:fail@example.com").
+		SetAlignment(DMARCStrict, DMARCRelaxed)
+	require.NoError(t, dmarc.Validate())
+	assert.Equal(t, "v=DMARC1; p=quarantine; sp=reject; pct=0; rua=mailto:agg@example.com,mailto:agg@example.net!10m; "+
+		"ruf=mailto:fail@example.com; adkim=s; aspf=r", dmarc.String())
+	assert.Equal(t, "_dmarc.example.com", DMARCName("example.com"))
+
+	parsed, err := ParseDMARC(dmarc.Record().ContentToString())
+	require.NoError(t, err)
+	assert.Equal(t, dmarc, parsed)
+
+	parsed, err = ParseDMARC(`"v=DMARC1;p=None;fo=1;ri=3600;x=ignored"`)
+	require.NoError(t, err)
+	assert.Equal(t, &DMARC{Policy: DMARCNone, FailureOptions: "1", ReportInterval: 3600}, parsed)
+
+	for _, bad := range []string{"p=reject; v=DMARC1", "v=DMARC1", "v=DMARC1; p=block", "v=DMARC1; p=none; pct=101",
+		"v=DMARC1; p=none; adkim=x", "v=DMARC1; p=none; rua=agg@example.com", "v=DMARC1; p=none; rua=mailto:nobody"} {
+		_, err := ParseDMARC(bad)
+		assert.ErrorIs(t, err, ErrInvalidDMARC, bad)
+	}
+}
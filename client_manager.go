@@ -0,0 +1,338 @@
+package dnssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownAccount returned for account which was not added to Manager
+var ErrUnknownAccount = errors.New("unknown account")
+
+// ErrNoOwner returned when no account of Manager owns the zone
+var ErrNoOwner = errors.New("zone is not owned by any account")
+
+// ForClient copy of Client which scopes every call to client of reseller by client_id parameter.
+// Copy has own response cache and dry-run change log when they are enabled.
+func (c *Client) ForClient(clientID uint64) *Client {
+	scoped := *c
+	scoped.clientID = clientID
+	if c.cache != nil {
+		scoped.cache = newResponseCache(c.cache.ttl, c.cache.maxEntries)
+	}
+	if c.dryRun != nil {
+		scoped.dryRun = &dryRunLog{}
+	}
+	return &scoped
+}
+
+// AccountZone zone found in account of Manager
+type AccountZone struct {
+	Account string
+	Zone    Zone
+}
+
+// AccountError failed account of Manager fan-out
+type AccountError struct {
+	Account string
+	Err     error
+}
+
+// Error implementation
+func (e AccountError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Account, e.Err)
+}
+
+// Unwrap implementation
+func (e AccountError) Unwrap() error {
+	return e.Err
+}
+
+// AccountsError lists accounts failed in Manager fan-out
+type AccountsError struct {
+	Accounts []AccountError
+}
+
+// Error implementation
+func (e *AccountsError) Error() string {
+	msgs := make([]string, len(e.Accounts))
+	for i, a := range e.Accounts {
+		msgs[i] = a.Error()
+	}
+	return fmt.Sprintf("%d accounts failed: %s", len(e.Accounts), strings.Join(msgs, "; "))
+}
+
+// Is matches target against errors of every failed account
+func (e *AccountsError) Is(target error) bool {
+	return errorsIs(e.errs(), target)
+}
+
+// As finds the first error of failed accounts matching target
+func (e *AccountsError) As(target any) bool {
+	return errorsAs(e.errs(), target)
+}
+
+func (e *AccountsError) errs() []error {
+	errs := make([]error, len(e.Accounts))
+	for i, a := range e.Accounts {
+		errs[i] = a
+	}
+	return errs
+}
+
+// Manager holds clients of many accounts and routes zone operations to the account owning the zone.
+// Owners are looked up once and remembered, Refresh rebuilds them from zone lists of all accounts.
+type Manager struct {
+	// Workers amount of accounts queried in parallel, 10 when empty
+	Workers int
+
+	mu       sync.RWMutex
+	names    []string
+	accounts map[string]API
+	owners   map[string]string
+}
+
+// NewManager constructor of Manager
+func NewManager() *Manager {
+	return &Manager{accounts: map[string]API{}, owners: map[string]string{}}
+}
+
+// Add account by name, the same name replaces the account
+func (m *Manager) Add(name string, api API) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[name]; !ok {
+		m.names = append(m.names, name)
+	}
+	m.accounts[name] = api
+	m.forgetAccountLocked(name)
+}
+
+// AddResellerClient adds account of reseller client, every call is scoped to clientID
+func (m *Manager) AddResellerClient(name string, reseller *Client, clientID uint64) {
+	m.Add(name, reseller.ForClient(clientID))
+}
+
+// Remove account with remembered owners of its zones
+func (m *Manager) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[name]; !ok {
+		return
+	}
+	delete(m.accounts, name)
+	for i, n := range m.names {
+		if n == name {
+			m.names = append(m.names[:i], m.names[i+1:]...)
+			break
+		}
+	}
+	m.forgetAccountLocked(name)
+}
+
+// Accounts names in order of adding
+func (m *Manager) Accounts() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]string{}, m.names...)
+}
+
+// Account by name
+func (m *Manager) Account(name string) (API, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	api, ok := m.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAccount, name)
+	}
+	return api, nil
+}
+
+// Owner name of account owning the zone, accounts are asked in parallel when owner is not known yet
+func (m *Manager) Owner(ctx context.Context, zone string) (string, error) {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	m.mu.RLock()
+	owner, ok := m.owners[zone]
+	m.mu.RUnlock()
+	if ok {
+		return owner, nil
+	}
+
+	names := m.Accounts()
+	found := make([]bool, len(names))
+	err := m.fanOut(ctx, names, func(ctx context.Context, name string, api API) error {
+		_, errGet := api.Zone(ctx, zone)
+		switch {
+		case errGet == nil:
+			for i, n := range names {
+				if n == name {
+					found[i] = true
+				}
+			}
+			return nil
+		case isNotFound(errGet):
+			return nil
+		default:
+			return errGet
+		}
+	})
+	for i, name := range names {
+		if found[i] {
+			m.remember(zone, name)
+			return name, nil
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("owner of %s: %w", zone, err)
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrNoOwner, zone)
+}
+
+// ForZone client of account owning the zone
+func (m *Manager) ForZone(ctx context.Context, zone string) (API, error) {
+	owner, err := m.Owner(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	return m.Account(owner)
+}
+
+// Refresh rebuilds owners of zones from zone lists of all accounts
+func (m *Manager) Refresh(ctx context.Context) error {
+	zones, err := m.AllZones(ctx, nil)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// owners of failed accounts are kept
+	failed := map[string]bool{}
+	var accountsErr *AccountsError
+	if errors.As(err, &accountsErr) {
+		for _, a := range accountsErr.Accounts {
+			failed[a.Account] = true
+		}
+	}
+	for zone, owner := range m.owners {
+		if !failed[owner] {
+			delete(m.owners, zone)
+		}
+	}
+	for _, z := range zones {
+		name := strings.ToLower(z.Zone.Name)
+		if _, ok := m.owners[name]; !ok {
+			m.owners[name] = z.Account
+		}
+	}
+
+	return err
+}
+
+// AllZones of every account sorted by account order and zone name.
+// Zones of succeeded accounts are returned together with *AccountsError describing failed ones.
+func (m *Manager) AllZones(ctx context.Context, nameFilters []string) ([]AccountZone, error) {
+	names := m.Accounts()
+	zones := make([][]Zone, len(names))
+	index := map[string]int{}
+	for i, name := range names {
+		index[name] = i
+	}
+
+	err := m.fanOut(ctx, names, func(ctx context.Context, name string, api API) error {
+		res, errList := api.AllZones(ctx, nameFilters)
+		zones[index[name]] = res
+		return errList
+	})
+
+	var res []AccountZone
+	for i, name := range names {
+		list := zones[i]
+		sort.SliceStable(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+		for _, z := range list {
+			res = append(res, AccountZone{Account: name, Zone: z})
+		}
+	}
+
+	return res, err
+}
+
+// FanOut calls fn for every account in parallel, error is *AccountsError of failed accounts
+func (m *Manager) FanOut(ctx context.Context, fn func(ctx context.Context, account string, api API) error) error {
+	return m.fanOut(ctx, m.Accounts(), fn)
+}
+
+// CreateZone in account and remember it as owner
+func (m *Manager) CreateZone(ctx context.Context, account string, addZone AddZone) (uint64, error) {
+	api, err := m.Account(account)
+	if err != nil {
+		return 0, err
+	}
+	id, err := api.CreateZone(ctx, addZone)
+	if err != nil {
+		return 0, err
+	}
+	m.remember(strings.ToLower(strings.TrimSuffix(addZone.Name, ".")), account)
+	return id, nil
+}
+
+// DeleteZone in account owning it
+func (m *Manager) DeleteZone(ctx context.Context, zone string) error {
+	api, err := m.ForZone(ctx, zone)
+	if err != nil {
+		return err
+	}
+	if err = api.DeleteZone(ctx, zone); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	delete(m.owners, strings.ToLower(strings.TrimSuffix(zone, ".")))
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Manager) remember(zone, account string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[account]; ok {
+		m.owners[zone] = account
+	}
+}
+
+func (m *Manager) forgetAccountLocked(name string) {
+	for zone, owner := range m.owners {
+		if owner == name {
+			delete(m.owners, zone)
+		}
+	}
+}
+
+func (m *Manager) fanOut(ctx context.Context, names []string,
+	fn func(ctx context.Context, account string, api API) error) error {
+	res := runBulk(ctx, len(names), BulkOptions{Workers: m.Workers}, func(ctx context.Context, i int) BulkItemResult {
+		api, err := m.Account(names[i])
+		if err == nil {
+			err = fn(ctx, names[i], api)
+		}
+		return BulkItemResult{Item: names[i], Err: err}
+	})
+
+	var failed []AccountError
+	for _, item := range res.Items {
+		if item.Err != nil {
+			failed = append(failed, AccountError{Account: item.Item, Err: item.Err})
+		}
+	}
+	if len(failed) > 0 {
+		return &AccountsError{Accounts: failed}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package dnssdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountServer serves zones of one account, zone lookups are counted
+func accountServer(t *testing.T, zones ...string) (*Client, *int32) {
+	t.Helper()
+
+	var lookups int32
+	mux, client := setupTest(t)
+	mux.HandleFunc("/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		res := ListZones{TotalAmount: len(zones)}
+		for _, z := range zones {
+			res.Zones = append(res.Zones, Zone{Name: z})
+		}
+		handleJSONResponse(res)(rw, req)
+	})
+	mux.HandleFunc("/v2/zones/", func(rw http.ResponseWriter, req *http.Request) {
+		name := strings.TrimPrefix(req.URL.Path, "/v2/zones/")
+		if req.Method == http.MethodGet {
+			atomic.AddInt32(&lookups, 1)
+		}
+		for _, z := range zones {
+			if z == name {
+				handleJSONResponse(Zone{Name: z})(rw, req)
+				return
+			}
+		}
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"error":"zone not found"}`))
+	})
+
+	return client, &lookups
+}
+
+func TestManager_routing(t *testing.T) {
+	first, firstLookups := accountServer(t, "a.com", "b.com")
+	second, secondLookups := accountServer(t, "c.com")
+
+	m := NewManager()
+	m.Add("first", first)
+	m.Add("second", second)
+	assert.Equal(t, []string{"first", "second"}, m.Accounts())
+
+	ctx := context.Background()
+	owner, err := m.Owner(ctx, "c.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "second", owner)
+
+	// owner is remembered
+	api, err := m.ForZone(ctx, "C.com")
+	require.NoError(t, err)
+	assert.Same(t, second, api)
+	assert.Equal(t, int32(1), atomic.LoadInt32(firstLookups))
+	assert.Equal(t, int32(1), atomic.LoadInt32(secondLookups))
+
+	_, err = m.Owner(ctx, "missing.com")
+	assert.True(t, errors.Is(err, ErrNoOwner))
+
+	_, err = m.Account("third")
+	assert.True(t, errors.Is(err, ErrUnknownAccount))
+}
+
+func TestManager_AllZonesAndRefresh(t *testing.T) {
+	first, firstLookups := accountServer(t, "b.com", "a.com")
+	second, _ := accountServer(t, "c.com")
+
+	m := NewManager()
+	m.Add("first", first)
+	m.Add("second", second)
+
+	ctx := context.Background()
+	zones, err := m.AllZones(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []AccountZone{
+		{Account: "first", Zone: Zone{Name: "a.com"}},
+		{Account: "first", Zone: Zone{Name: "b.com"}},
+		{Account: "second", Zone: Zone{Name: "c.com"}},
+	}, zones)
+
+	require.NoError(t, m.Refresh(ctx))
+	owner, err := m.Owner(ctx, "b.com")
+	require.NoError(t, err)
+	assert.Equal(t, "first", owner)
+	assert.Equal(t, int32(0), atomic.LoadInt32(firstLookups))
+
+	m.Remove("first")
+	_, err = m.Owner(ctx, "b.com")
+	assert.True(t, errors.Is(err, ErrNoOwner))
+}
+
+func TestManager_FanOutPartial(t *testing.T) {
+	ok, _ := accountServer(t, "a.com")
+	mux, broken := setupTest(t)
+	mux.HandleFunc("/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	})
+
+	m := NewManager()
+	m.Add("ok", ok)
+	m.Add("broken", broken)
+
+	zones, err := m.AllZones(context.Background(), nil)
+	assert.Equal(t, []AccountZone{{Account: "ok", Zone: Zone{Name: "a.com"}}}, zones)
+	var accountsErr *AccountsError
+	require.ErrorAs(t, err, &accountsErr)
+	require.Len(t, accountsErr.Accounts, 1)
+	assert.Equal(t, "broken", accountsErr.Accounts[0].Account)
+	var apiErr APIError
+	require.ErrorAs(t, err, &apiErr, "errors of accounts are matched")
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+}
+
+func TestManager_CreateDeleteZone(t *testing.T) {
+	mux, client := setupTest(t)
+	mux.HandleFunc("/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		handleJSONResponse(CreateResponse{ID: 7})(rw, req)
+	})
+	mux.HandleFunc("/v2/zones/new.com", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			t.Errorf("unexpected %s, owner should be remembered", req.Method)
+		}
+	})
+
+	m := NewManager()
+	m.Add("acc", client)
+
+	ctx := context.Background()
+	id, err := m.CreateZone(ctx, "acc", AddZone{Name: "new.com"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), id)
+	require.NoError(t, m.DeleteZone(ctx, "new.com"))
+}
+
+func TestClient_ForClient(t *testing.T) {
+	mux, reseller := setupTest(t)
+	mux.HandleFunc("/v2/zones/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("client_id") != "42" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		handleJSONResponse(Zone{Name: "example.com", ClientID: 42})(rw, req)
+	})
+
+	m := NewManager()
+	m.AddResellerClient("client42", reseller, 42)
+
+	ctx := context.Background()
+	api, err := m.ForZone(ctx, "example.com")
+	require.NoError(t, err)
+	zone, err := api.Zone(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), zone.ClientID)
+
+	// reseller itself is not scoped
+	_, err = reseller.Zone(ctx, "example.com")
+	assert.True(t, isNotFound(err))
+}
@@ -0,0 +1,503 @@
+package dnssdk
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultMappingEntryPrefixes cap of prefixes in one imported mapping entry
+const DefaultMappingEntryPrefixes = 1000
+
+// ErrInvalidMappingImport bad source of network mapping import
+var ErrInvalidMappingImport = errors.New("invalid network mapping import")
+
+// MappingImportFormat of network mapping source
+type MappingImportFormat string
+
+// formats of network mapping sources
+const (
+	// MappingImportCSV rows of cidr,tag[,tag...], optional header and # comments
+	MappingImportCSV MappingImportFormat = "csv"
+	// MappingImportJSON array of {"cidr": "...", "tags": [...]} or network mapping object
+	MappingImportJSON MappingImportFormat = "json"
+	// MappingImportMaxMind CSV blocks of GeoLite2/GeoIP2 databases with network column,
+	// e.g. GeoLite2-ASN-Blocks-IPv4.csv, binary mmdb files are not supported
+	MappingImportMaxMind MappingImportFormat = "maxmind"
+)
+
+// maxMindASN column of ASN blocks, its values are tagged as AS<number>
+const maxMindASN = "autonomous_system_number"
+
+// MappingImportOptions of ImportNetworkMapping
+type MappingImportOptions struct {
+	// Name of mapping, name of JSON mapping object or file name without extension by default
+	Name string
+	// Format of source, CSV or MaxMind CSV by header is detected by default
+	Format MappingImportFormat
+	// TagColumns of MaxMind CSV used as tags,
+	// autonomous_system_number or geoname_id by default
+	TagColumns []string
+	// MaxPrefixes of one entry, larger entries are split, DefaultMappingEntryPrefixes by default
+	MaxPrefixes int
+	// NoCollapse keeps prefixes as they are read
+	NoCollapse bool
+	// Strict fails on the first invalid row instead of skipping it
+	Strict bool
+}
+
+// MappingImportSkip row of source which is not imported
+type MappingImportSkip struct {
+	// Row line of CSV or index of JSON element starting from 1
+	Row    int
+	Reason string
+}
+
+// MappingImportReport summary of network mapping import
+type MappingImportReport struct {
+	// Rows of source with prefixes
+	Rows    int
+	Skipped []MappingImportSkip
+	// Prefixes distinct prefixes read
+	Prefixes int
+	// IPv4Mapped IPv6 prefixes converted to IPv4 ones
+	IPv4Mapped int
+	// Collapsed prefixes of mapping after aggregation
+	Collapsed int
+	Entries   int
+	// Split entries added because of MaxPrefixes
+	Split int
+	Tags  int
+}
+
+// String implementation
+func (r MappingImportReport) String() string {
+	return fmt.Sprintf("%d rows, %d skipped, %d prefixes collapsed to %d in %d entries of %d tags",
+		r.Rows, len(r.Skipped), r.Prefixes, r.Collapsed, r.Entries, r.Tags)
+}
+
+// ImportNetworkMappingFile reads network mapping from local file, format is detected by extension
+func ImportNetworkMappingFile(path string, opts MappingImportOptions) (NetworkMappingRequest, MappingImportReport, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if opts.Format == "" {
+		switch ext {
+		case ".json":
+			opts.Format = MappingImportJSON
+		case ".mmdb":
+			// nolint: goerr113
+			return NetworkMappingRequest{}, MappingImportReport{},
+				fmt.Errorf("%w: binary MaxMind DB %s is not supported, use its CSV blocks", ErrInvalidMappingImport, path)
+		}
+	}
+	if opts.Name == "" {
+		opts.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return NetworkMappingRequest{}, MappingImportReport{}, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	res, report, err := ImportNetworkMapping(f, opts)
+	if err != nil {
+		return res, report, fmt.Errorf("import %s: %w", path, err)
+	}
+	return res, report, nil
+}
+
+// ImportNetworkMapping reads prefixes with tags and builds mapping of them.
+// Tags of the same prefix are merged, IPv4-mapped IPv6 prefixes are imported as IPv4 ones,
+// prefixes with the same tags are collapsed: contained prefixes are dropped and adjacent ones are merged
+// while the longest prefix match of every address stays the same.
+func ImportNetworkMapping(r io.Reader, opts MappingImportOptions) (NetworkMappingRequest, MappingImportReport, error) {
+	imp := &mappingImport{opts: opts, prefixes: map[string]*importedPrefix{}}
+	var err error
+	switch opts.Format {
+	case MappingImportJSON:
+		err = imp.readJSON(r)
+	case "", MappingImportCSV, MappingImportMaxMind:
+		err = imp.readCSV(r)
+	default:
+		// nolint: goerr113
+		err = fmt.Errorf("%w: unknown format %s", ErrInvalidMappingImport, opts.Format)
+	}
+	if err != nil {
+		return NetworkMappingRequest{}, imp.report, err
+	}
+	imp.report.Prefixes = len(imp.prefixes)
+	if !opts.NoCollapse {
+		collapsePrefixes(imp.prefixes)
+	}
+	return NetworkMappingRequest{Name: imp.opts.Name, Mapping: imp.entries()}, imp.report, nil
+}
+
+type mappingImport struct {
+	opts     MappingImportOptions
+	prefixes map[string]*importedPrefix
+	report   MappingImportReport
+}
+
+// importedPrefix with sorted tags, key joins tags
+type importedPrefix struct {
+	prefix net.IPNet
+	tags   []string
+	key    string
+}
+
+// add prefix of row, invalid row is skipped or fails import when strict
+func (imp *mappingImport) add(row int, cidr string, tags []string) error {
+	var clean []string
+	for _, t := range tags {
+		if t = strings.TrimSpace(t); t != "" && !containsString(clean, t) {
+			clean = append(clean, t)
+		}
+	}
+	prefix, mapped, err := parseImportPrefix(cidr)
+	switch {
+	case err != nil:
+		return imp.skip(row, err.Error())
+	case len(clean) == 0:
+		return imp.skip(row, fmt.Sprintf("%s has no tags", cidr))
+	}
+	imp.report.Rows++
+	if mapped {
+		imp.report.IPv4Mapped++
+	}
+	if p, ok := imp.prefixes[prefix.String()]; ok {
+		clean = append(clean, p.tags...)
+	}
+	imp.prefixes[prefix.String()] = newImportedPrefix(prefix, clean)
+	return nil
+}
+
+func (imp *mappingImport) skip(row int, reason string) error {
+	if imp.opts.Strict {
+		// nolint: goerr113
+		return fmt.Errorf("%w: row %d: %s", ErrInvalidMappingImport, row, reason)
+	}
+	imp.report.Skipped = append(imp.report.Skipped, MappingImportSkip{Row: row, Reason: reason})
+	return nil
+}
+
+func (imp *mappingImport) readCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	var columns []int
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMappingImport, err)
+		}
+		row, _ := reader.FieldPos(0)
+		cidr := strings.TrimSpace(record[0])
+		if first && !strings.ContainsAny(cidr, ".:") {
+			// header
+			if imp.maxMind(record) {
+				if columns, err = imp.tagColumns(record); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if first && imp.opts.Format == MappingImportMaxMind {
+			// nolint: goerr113
+			return fmt.Errorf("%w: MaxMind CSV has no header", ErrInvalidMappingImport)
+		}
+
+		tags := record[1:]
+		if columns != nil {
+			tags = nil
+			for i, col := range columns {
+				if col < len(record) && strings.TrimSpace(record[col]) != "" {
+					tags = append(tags, maxMindTag(imp.opts.TagColumns[i], record[col]))
+				}
+			}
+		}
+		if err = imp.add(row, cidr, tags); err != nil {
+			return err
+		}
+	}
+}
+
+// maxMind layout is header with network column and tag columns
+func (imp *mappingImport) maxMind(header []string) bool {
+	if imp.opts.Format == MappingImportMaxMind {
+		return true
+	}
+	if imp.opts.Format == MappingImportCSV || strings.TrimSpace(header[0]) != "network" {
+		return false
+	}
+	return len(imp.opts.TagColumns) > 0 || containsString(header, maxMindASN) || containsString(header, "geoname_id")
+}
+
+// tagColumns indexes of TagColumns in header, TagColumns are set to found ones
+func (imp *mappingImport) tagColumns(header []string) ([]int, error) {
+	if len(imp.opts.TagColumns) == 0 {
+		for _, col := range []string{maxMindASN, "geoname_id"} {
+			if containsString(header, col) {
+				imp.opts.TagColumns = []string{col}
+				break
+			}
+		}
+	}
+	if len(imp.opts.TagColumns) == 0 {
+		// nolint: goerr113
+		return nil, fmt.Errorf("%w: tag columns of MaxMind CSV are required", ErrInvalidMappingImport)
+	}
+	columns := make([]int, 0, len(imp.opts.TagColumns))
+	for _, name := range imp.opts.TagColumns {
+		idx := -1
+		for i, col := range header {
+			if strings.TrimSpace(col) == name {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			// nolint: goerr113
+			return nil, fmt.Errorf("%w: column %s not found in MaxMind CSV", ErrInvalidMappingImport, name)
+		}
+		columns = append(columns, idx)
+	}
+	return columns, nil
+}
+
+func maxMindTag(column, value string) string {
+	value = strings.TrimSpace(value)
+	if column == maxMindASN {
+		return "AS" + value
+	}
+	return value
+}
+
+func (imp *mappingImport) readJSON(r io.Reader) error {
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	bs = bytes.TrimSpace(bs)
+
+	if bytes.HasPrefix(bs, []byte("[")) {
+		var rows []struct {
+			CIDR string   `json:"cidr"`
+			Tags []string `json:"tags"`
+		}
+		if err = json.Unmarshal(bs, &rows); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMappingImport, err)
+		}
+		for i, row := range rows {
+			if err = imp.add(i+1, row.CIDR, row.Tags); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// cidrs are strings to skip invalid ones instead of failing decode
+	var mapping struct {
+		Name    string `json:"name"`
+		Mapping []struct {
+			CIDR4 []string `json:"cidr4"`
+			CIDR6 []string `json:"cidr6"`
+			Tags  []string `json:"tags"`
+		} `json:"mapping"`
+	}
+	if err = json.Unmarshal(bs, &mapping); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMappingImport, err)
+	}
+	if imp.opts.Name == "" {
+		imp.opts.Name = mapping.Name
+	}
+	for i, entry := range mapping.Mapping {
+		for _, cidr := range append(entry.CIDR4, entry.CIDR6...) {
+			if err = imp.add(i+1, cidr, entry.Tags); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// entries of prefixes grouped by tags, entries over MaxPrefixes are split
+func (imp *mappingImport) entries() []MappingEntry {
+	limit := imp.opts.MaxPrefixes
+	if limit <= 0 {
+		limit = DefaultMappingEntryPrefixes
+	}
+	tree := NewPrefixTree()
+	for key := range imp.prefixes {
+		p := imp.prefixes[key]
+		tree.Insert(p.prefix, p.key)
+	}
+	groups := map[string][]net.IPNet{}
+	var keys []string
+	// address order of tree
+	tree.Walk(func(m PrefixMatch) {
+		key := m.Tags[0]
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], m.Prefix)
+	})
+	sort.Strings(keys)
+
+	var res []MappingEntry
+	for _, key := range keys {
+		prefixes := groups[key]
+		tags := imp.prefixes[prefixes[0].String()].tags
+		for start := 0; start < len(prefixes); start += limit {
+			end := start + limit
+			if end > len(prefixes) {
+				end = len(prefixes)
+			}
+			entry := MappingEntry{Tags: append([]string{}, tags...)}
+			for _, p := range prefixes[start:end] {
+				if len(p.Mask) == net.IPv4len {
+					entry.CIDR4 = append(entry.CIDR4, IPNet{p})
+				} else {
+					entry.CIDR6 = append(entry.CIDR6, IPNet{p})
+				}
+			}
+			if start > 0 {
+				imp.report.Split++
+			}
+			res = append(res, entry)
+		}
+	}
+	imp.report.Collapsed = len(imp.prefixes)
+	imp.report.Entries = len(res)
+	imp.report.Tags = len(keys)
+	return res
+}
+
+func newImportedPrefix(prefix net.IPNet, tags []string) *importedPrefix {
+	sorted := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if !containsString(sorted, t) {
+			sorted = append(sorted, t)
+		}
+	}
+	sort.Strings(sorted)
+	return &importedPrefix{prefix: prefix, tags: sorted, key: strings.Join(sorted, "\x00")}
+}
+
+// parseImportPrefix of CIDR or address, IPv4-mapped IPv6 prefix is returned as IPv4 one
+func parseImportPrefix(s string) (net.IPNet, bool, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			// nolint: goerr113
+			return net.IPNet{}, false, fmt.Errorf("invalid prefix %q", s)
+		}
+		if v4 := ip.To4(); v4 != nil {
+			return net.IPNet{IP: v4, Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)}, strings.Contains(s, ":"), nil
+		}
+		return net.IPNet{IP: ip, Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)}, false, nil
+	}
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		// nolint: goerr113
+		return net.IPNet{}, false, fmt.Errorf("invalid prefix %q", s)
+	}
+	ones, bits := n.Mask.Size()
+	const mappedPrefix = (net.IPv6len - net.IPv4len) * 8
+	if bits == net.IPv6len*8 && ones >= mappedPrefix && n.IP.To4() != nil {
+		return net.IPNet{IP: n.IP.To4(), Mask: net.CIDRMask(ones-mappedPrefix, net.IPv4len*8)}, true, nil
+	}
+	return *n, false, nil
+}
+
+// collapsePrefixes drops redundant prefixes and merges adjacent ones with the same tags until nothing changes
+func collapsePrefixes(prefixes map[string]*importedPrefix) {
+	for {
+		dropped := dropRedundantPrefixes(prefixes)
+		merged := mergeAdjacentPrefixes(prefixes)
+		if !dropped && !merged {
+			return
+		}
+	}
+}
+
+// dropRedundantPrefixes with the same tags as their nearest containing prefix
+func dropRedundantPrefixes(prefixes map[string]*importedPrefix) bool {
+	tree := NewPrefixTree()
+	for key, p := range prefixes {
+		tree.Insert(p.prefix, key)
+	}
+	var redundant []string
+	for key, p := range prefixes {
+		covering := tree.Covering(p.prefix)
+		if len(covering) < 2 {
+			continue
+		}
+		parent := prefixes[covering[len(covering)-2].Tags[0]]
+		if parent.key == p.key {
+			redundant = append(redundant, key)
+		}
+	}
+	for _, key := range redundant {
+		delete(prefixes, key)
+	}
+	return len(redundant) > 0
+}
+
+// mergeAdjacentPrefixes replaces sibling prefixes with the same tags by their parent,
+// parent already listed with other tags is kept as is
+func mergeAdjacentPrefixes(prefixes map[string]*importedPrefix) bool {
+	keys := make([]string, 0, len(prefixes))
+	for key := range prefixes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	merged := false
+	for _, key := range keys {
+		p, ok := prefixes[key]
+		if !ok {
+			continue
+		}
+		parent, sibling, ok := prefixParent(p.prefix)
+		if !ok {
+			continue
+		}
+		other, ok := prefixes[sibling.String()]
+		if !ok || other.key != p.key {
+			continue
+		}
+		if _, ok = prefixes[parent.String()]; ok {
+			continue
+		}
+		delete(prefixes, key)
+		delete(prefixes, sibling.String())
+		prefixes[parent.String()] = &importedPrefix{prefix: parent, tags: p.tags, key: p.key}
+		merged = true
+	}
+	return merged
+}
+
+// prefixParent one bit shorter prefix and the other half of it
+func prefixParent(n net.IPNet) (net.IPNet, net.IPNet, bool) {
+	ones, bits := n.Mask.Size()
+	if ones == 0 {
+		return net.IPNet{}, net.IPNet{}, false
+	}
+	mask := net.CIDRMask(ones-1, bits)
+	ip := append(net.IP{}, n.IP...)
+	// nolint: gomnd
+	ip[(ones-1)/8] ^= 1 << (7 - uint((ones-1)%8))
+	return net.IPNet{IP: n.IP.Mask(mask), Mask: mask}, net.IPNet{IP: ip, Mask: n.Mask}, true
+}
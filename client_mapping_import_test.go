@@ -0,0 +1,169 @@
+package dnssdk
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entryStrings(entries []MappingEntry) []string {
+	var res []string
+	for _, e := range entries {
+		var cidrs []string
+		for _, n := range append(append([]IPNet{}, e.CIDR4...), e.CIDR6...) {
+			cidrs = append(cidrs, n.String())
+		}
+		res = append(res, strings.Join(e.Tags, "+")+"="+strings.Join(cidrs, ","))
+	}
+	return res
+}
+
+func TestImportNetworkMapping_CSV(t *testing.T) {
+	src := `cidr,tags
+# office networks
+10.0.0.0/25,office
+10.0.0.128/25,office
+10.0.1.0/24,office
+10.0.1.10,office
+10.0.1.64/26,lab
+10.0.1.64/27,lab
+::ffff:192.0.2.0/120,dc
+192.0.2.0/24,eu
+2001:db8::/33, v6
+2001:db8:8000::/33,v6
+not-a-cidr,office
+198.51.100.0/24
+`
+	mapping, report, err := ImportNetworkMapping(strings.NewReader(src), MappingImportOptions{Name: "ipam"})
+	require.NoError(t, err)
+	assert.Equal(t, "ipam", mapping.Name)
+	assert.Equal(t, []string{
+		"dc+eu=192.0.2.0/24",
+		"lab=10.0.1.64/26",
+		"office=10.0.0.0/23",
+		"v6=2001:db8::/32",
+	}, entryStrings(mapping.Mapping))
+	assert.NoError(t, mapping.Validate())
+
+	assert.Equal(t, 10, report.Rows)
+	assert.Equal(t, []MappingImportSkip{
+		{Row: 13, Reason: `invalid prefix "not-a-cidr"`},
+		{Row: 14, Reason: "198.51.100.0/24 has no tags"},
+	}, report.Skipped)
+	assert.Equal(t, 9, report.Prefixes)
+	assert.Equal(t, 1, report.IPv4Mapped)
+	assert.Equal(t, 4, report.Collapsed)
+	assert.Equal(t, "10 rows, 2 skipped, 9 prefixes collapsed to 4 in 4 entries of 4 tags", report.String())
+
+	_, _, err = ImportNetworkMapping(strings.NewReader(src), MappingImportOptions{Strict: true})
+	assert.True(t, errors.Is(err, ErrInvalidMappingImport))
+	assert.ErrorContains(t, err, "row 13")
+}
+
+func TestImportNetworkMapping_collapseKeepsLookups(t *testing.T) {
+	src := `10.0.0.0/8,dc
+10.1.0.0/16,office
+10.1.0.0/17,dc
+10.1.128.0/17,dc
+10.2.0.0/16,dc
+10.3.0.0/24,dc
+10.3.1.0/24,dc
+`
+	raw, _, err := ImportNetworkMapping(strings.NewReader(src), MappingImportOptions{NoCollapse: true})
+	require.NoError(t, err)
+	mapping, report, err := ImportNetworkMapping(strings.NewReader(src), MappingImportOptions{})
+	require.NoError(t, err)
+
+	// office /16 is fully overridden by both dc halves, the rest is contained in 10.0.0.0/8
+	assert.Equal(t, []string{"dc=10.0.0.0/8,10.1.0.0/17,10.1.128.0/17", "office=10.1.0.0/16"},
+		entryStrings(mapping.Mapping))
+	assert.Equal(t, 7, report.Prefixes)
+	assert.Equal(t, 4, report.Collapsed)
+	for _, ip := range []string{"10.0.0.1", "10.1.0.1", "10.1.200.1", "10.2.3.4", "10.3.1.1", "11.0.0.1"} {
+		assert.Equal(t, raw.TagsFor(net.ParseIP(ip)), mapping.TagsFor(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestImportNetworkMapping_split(t *testing.T) {
+	var src strings.Builder
+	for _, cidr := range []string{"10.0.0.0/24", "10.0.2.0/24", "10.0.4.0/24", "10.0.6.0/24", "10.0.8.0/24"} {
+		src.WriteString(cidr + ",a\n")
+	}
+	mapping, report, err := ImportNetworkMapping(strings.NewReader(src.String()), MappingImportOptions{MaxPrefixes: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a=10.0.0.0/24,10.0.2.0/24", "a=10.0.4.0/24,10.0.6.0/24", "a=10.0.8.0/24"},
+		entryStrings(mapping.Mapping))
+	assert.Equal(t, 3, report.Entries)
+	assert.Equal(t, 2, report.Split)
+}
+
+func TestImportNetworkMapping_JSON(t *testing.T) {
+	rows := `[{"cidr": "203.0.113.0/25", "tags": ["edge"]}, {"cidr": "203.0.113.128/25", "tags": ["edge"]},
+		{"cidr": "203.0.113.300/25", "tags": ["edge"]}]`
+	mapping, report, err := ImportNetworkMapping(strings.NewReader(rows), MappingImportOptions{Format: MappingImportJSON})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edge=203.0.113.0/24"}, entryStrings(mapping.Mapping))
+	assert.Equal(t, []MappingImportSkip{{Row: 3, Reason: `invalid prefix "203.0.113.300/25"`}}, report.Skipped)
+
+	object := `{"name": "exported", "mapping": [{"cidr4": ["10.0.0.0/9", "10.128.0.0/9"], "cidr6": ["fd00::/8"],
+		"tags": ["dc"]}]}`
+	mapping, _, err = ImportNetworkMapping(strings.NewReader(object), MappingImportOptions{Format: MappingImportJSON})
+	require.NoError(t, err)
+	assert.Equal(t, "exported", mapping.Name)
+	assert.Equal(t, []string{"dc=10.0.0.0/8,fd00::/8"}, entryStrings(mapping.Mapping))
+}
+
+func TestImportNetworkMapping_MaxMind(t *testing.T) {
+	asn := `network,autonomous_system_number,autonomous_system_organization
+1.0.0.0/24,13335,CLOUDFLARENET
+1.0.1.0/24,13335,CLOUDFLARENET
+1.0.4.0/22,38803,"Wirefreebroadband, Pty Ltd"
+1.0.64.0/18,,
+`
+	mapping, report, err := ImportNetworkMapping(strings.NewReader(asn), MappingImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AS13335=1.0.0.0/23", "AS38803=1.0.4.0/22"}, entryStrings(mapping.Mapping))
+	assert.Equal(t, []MappingImportSkip{{Row: 5, Reason: "1.0.64.0/18 has no tags"}}, report.Skipped)
+
+	country := `network,geoname_id,registered_country_geoname_id,represented_country_geoname_id
+2001:db8::/32,2921044,2921044,
+198.51.100.0/24,,6252001,
+`
+	mapping, _, err = ImportNetworkMapping(strings.NewReader(country), MappingImportOptions{
+		TagColumns: []string{"geoname_id", "registered_country_geoname_id"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2921044=2001:db8::/32", "6252001=198.51.100.0/24"}, entryStrings(mapping.Mapping))
+
+	_, _, err = ImportNetworkMapping(strings.NewReader(asn), MappingImportOptions{TagColumns: []string{"country"}})
+	assert.ErrorContains(t, err, "column country not found")
+	_, _, err = ImportNetworkMapping(strings.NewReader("1.0.0.0/24,13335\n"), MappingImportOptions{
+		Format: MappingImportMaxMind})
+	assert.ErrorContains(t, err, "has no header")
+}
+
+func TestImportNetworkMappingFile(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "office.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("10.0.0.0/8,office\n"), 0o600))
+	jsonPath := filepath.Join(dir, "edge.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`[{"cidr": "fd00::/8", "tags": ["edge"]}]`), 0o600))
+
+	mapping, _, err := ImportNetworkMappingFile(csvPath, MappingImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, NetworkMappingRequest{Name: "office", Mapping: mappingOf("office", "10.0.0.0/8")}, mapping)
+
+	mapping, _, err = ImportNetworkMappingFile(jsonPath, MappingImportOptions{Name: "custom"})
+	require.NoError(t, err)
+	assert.Equal(t, NetworkMappingRequest{Name: "custom", Mapping: mappingOf("edge", "fd00::/8")}, mapping)
+
+	_, _, err = ImportNetworkMappingFile(filepath.Join(dir, "GeoLite2-ASN.mmdb"), MappingImportOptions{})
+	assert.ErrorContains(t, err, "binary MaxMind DB")
+	_, _, err = ImportNetworkMappingFile(filepath.Join(dir, "missing.csv"), MappingImportOptions{})
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
@@ -0,0 +1,357 @@
+package dnssdk
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MappingIssueKind of network mapping validation issue
+type MappingIssueKind string
+
+// kinds of network mapping issues
+const (
+	// MappingDuplicate the same prefix is listed twice
+	MappingDuplicate MappingIssueKind = "duplicate"
+	// MappingOverlap prefix is contained in another one
+	MappingOverlap MappingIssueKind = "overlap"
+	// MappingIPv4Mapped IPv4-mapped IPv6 prefix, resolvers send IPv4 subnets as IPv4
+	MappingIPv4Mapped MappingIssueKind = "ipv4_mapped"
+	// MappingFamily IPv4 prefix in cidr6 or IPv6 prefix in cidr4
+	MappingFamily MappingIssueKind = "family"
+	// MappingEmptyTags entry has no tags
+	MappingEmptyTags MappingIssueKind = "empty_tags"
+	// MappingEmptyEntry entry has no prefixes
+	MappingEmptyEntry MappingIssueKind = "empty_entry"
+)
+
+// MappingIssue found by ValidateNetworkMapping, Entry and OtherEntry are indexes of Mapping
+type MappingIssue struct {
+	Severity   Severity
+	Kind       MappingIssueKind
+	Entry      int
+	CIDR       string
+	OtherEntry int
+	OtherCIDR  string
+	Message    string
+}
+
+// String implementation
+func (i MappingIssue) String() string {
+	return fmt.Sprintf("%s %s entry %d: %s", i.Severity, i.Kind, i.Entry, i.Message)
+}
+
+// MappingError of NetworkMappingRequest.Validate with every error issue
+type MappingError struct {
+	Name   string
+	Issues []MappingIssue
+}
+
+// Error implementation
+func (e *MappingError) Error() string {
+	msgs := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		msgs = append(msgs, issue.String())
+	}
+	return fmt.Sprintf("network mapping %s: %s", e.Name, strings.Join(msgs, "; "))
+}
+
+// Validate returns *MappingError when mapping has issues of error severity:
+// the same prefix with different tags, prefixes of wrong family, entries without tags
+func (r NetworkMappingRequest) Validate() error {
+	var errs []MappingIssue
+	for _, issue := range ValidateNetworkMapping(r) {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	if len(errs) > 0 {
+		return &MappingError{Name: r.Name, Issues: errs}
+	}
+	return nil
+}
+
+// TagsFor returns tags of the longest prefix matching ip
+func (r NetworkMappingRequest) TagsFor(ip net.IP) []string {
+	tags, _, _ := NewMappingTree(r.Mapping).Lookup(ip)
+	return tags
+}
+
+// ValidateNetworkMapping finds duplicates and overlaps of prefixes, IPv4-mapped IPv6 prefixes,
+// prefixes of wrong family and entries without tags or prefixes.
+// Overlap of prefixes with different tags is info, the longest prefix wins.
+func ValidateNetworkMapping(r NetworkMappingRequest) []MappingIssue {
+	var res []MappingIssue
+	tree := NewPrefixTree()
+	type owned struct {
+		entry int
+		cidr  net.IPNet
+	}
+	var prefixes []owned
+
+	for i, entry := range r.Mapping {
+		if len(entry.Tags) == 0 || emptyTag(entry.Tags) {
+			res = append(res, MappingIssue{Severity: SeverityError, Kind: MappingEmptyTags, Entry: i, OtherEntry: -1,
+				Message: "entry has empty tags"})
+		}
+		if len(entry.CIDR4)+len(entry.CIDR6) == 0 {
+			res = append(res, MappingIssue{Severity: SeverityWarning, Kind: MappingEmptyEntry, Entry: i, OtherEntry: -1,
+				Message: "entry has no prefixes"})
+		}
+		check := func(list []IPNet, v6 bool) {
+			for _, n := range list {
+				isV6 := len(n.Mask) == net.IPv6len
+				switch {
+				case isV6 != v6:
+					res = append(res, MappingIssue{Severity: SeverityError, Kind: MappingFamily, Entry: i,
+						CIDR: n.String(), OtherEntry: -1, Message: fmt.Sprintf("%s is listed in %s", n, familyList(v6))})
+				case isV6 && n.IP.To4() != nil:
+					res = append(res, MappingIssue{Severity: SeverityWarning, Kind: MappingIPv4Mapped, Entry: i,
+						CIDR: n.String(), OtherEntry: -1,
+						Message: fmt.Sprintf("IPv4-mapped IPv6 prefix is sent as %s in cidr6, move it to cidr4", n)})
+				}
+				prefixes = append(prefixes, owned{entry: i, cidr: n.IPNet})
+				tree.Insert(n.IPNet, strconv.Itoa(len(prefixes)-1))
+			}
+		}
+		check(entry.CIDR4, false)
+		check(entry.CIDR6, true)
+	}
+
+	for idx, p := range prefixes {
+		covering := tree.Covering(p.cidr)
+		// the last one is prefix itself with its duplicates, the previous one is the nearest containing prefix
+		self := covering[len(covering)-1]
+		for _, owner := range self.Tags {
+			other, _ := strconv.Atoi(owner)
+			if other < idx {
+				res = append(res, overlapIssue(r, p.entry, p.cidr, prefixes[other].entry, p.cidr, true))
+			}
+		}
+		if len(covering) < 2 {
+			continue
+		}
+		parent := covering[len(covering)-2]
+		for _, owner := range parent.Tags {
+			other, _ := strconv.Atoi(owner)
+			res = append(res, overlapIssue(r, p.entry, p.cidr, prefixes[other].entry, parent.Prefix, false))
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Severity != res[j].Severity {
+			return res[i].Severity > res[j].Severity
+		}
+		return res[i].Entry < res[j].Entry
+	})
+	return res
+}
+
+func overlapIssue(r NetworkMappingRequest, entry int, cidr net.IPNet, other int, otherCIDR net.IPNet, same bool) MappingIssue {
+	issue := MappingIssue{Entry: entry, CIDR: cidr.String(), OtherEntry: other, OtherCIDR: otherCIDR.String()}
+	sameTags := sameStrings(r.Mapping[entry].Tags, r.Mapping[other].Tags)
+	switch {
+	case same && sameTags:
+		issue.Severity, issue.Kind = SeverityWarning, MappingDuplicate
+		issue.Message = fmt.Sprintf("%s is listed again in entry %d", cidr.String(), other)
+	case same:
+		issue.Severity, issue.Kind = SeverityError, MappingDuplicate
+		issue.Message = fmt.Sprintf("%s has conflicting tags in entry %d", cidr.String(), other)
+	case sameTags:
+		issue.Severity, issue.Kind = SeverityWarning, MappingOverlap
+		issue.Message = fmt.Sprintf("%s is redundant, it is contained in %s of entry %d with the same tags",
+			cidr.String(), otherCIDR.String(), other)
+	default:
+		issue.Severity, issue.Kind = SeverityInfo, MappingOverlap
+		issue.Message = fmt.Sprintf("%s overrides tags of %s of entry %d", cidr.String(), otherCIDR.String(), other)
+	}
+	return issue
+}
+
+func familyList(v6 bool) string {
+	if v6 {
+		return "cidr6"
+	}
+	return "cidr4"
+}
+
+func emptyTag(tags []string) bool {
+	for _, t := range tags {
+		if strings.TrimSpace(t) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// PrefixTree binary radix tree of IPv4 and IPv6 prefixes with tags, lookups are longest prefix match
+type PrefixTree struct {
+	v4, v6 *prefixNode
+	size   int
+}
+
+type prefixNode struct {
+	child  [2]*prefixNode
+	prefix *net.IPNet
+	tags   []string
+}
+
+// PrefixMatch prefix of tree with its tags
+type PrefixMatch struct {
+	Prefix net.IPNet
+	Tags   []string
+}
+
+// NewPrefixTree empty tree
+func NewPrefixTree() *PrefixTree {
+	return &PrefixTree{v4: &prefixNode{}, v6: &prefixNode{}}
+}
+
+// NewMappingTree tree of prefixes of mapping entries with their tags
+func NewMappingTree(entries []MappingEntry) *PrefixTree {
+	tree := NewPrefixTree()
+	for _, e := range entries {
+		for _, n := range e.CIDR4 {
+			tree.Insert(n.IPNet, e.Tags...)
+		}
+		for _, n := range e.CIDR6 {
+			tree.Insert(n.IPNet, e.Tags...)
+		}
+	}
+	return tree
+}
+
+// Len amount of prefixes in tree
+func (t *PrefixTree) Len() int {
+	return t.size
+}
+
+// Insert prefix with tags, tags are added to tags of existing prefix
+func (t *PrefixTree) Insert(prefix net.IPNet, tags ...string) {
+	ip, bits := t.key(prefix)
+	node := t.root(prefix)
+	for i := 0; i < bits; i++ {
+		b := bit(ip, i)
+		if node.child[b] == nil {
+			node.child[b] = &prefixNode{}
+		}
+		node = node.child[b]
+	}
+	if node.prefix == nil {
+		p := net.IPNet{IP: ip.Mask(prefix.Mask), Mask: prefix.Mask}
+		node.prefix = &p
+		t.size++
+	}
+	for _, tag := range tags {
+		if !containsString(node.tags, tag) {
+			node.tags = append(node.tags, tag)
+		}
+	}
+}
+
+// Lookup tags of the longest prefix containing ip
+func (t *PrefixTree) Lookup(ip net.IP) ([]string, net.IPNet, bool) {
+	node, bits := t.v6, net.IPv6len*8
+	if v4 := ip.To4(); v4 != nil {
+		ip, node, bits = v4, t.v4, net.IPv4len*8
+	}
+	if len(ip) != bits/8 {
+		return nil, net.IPNet{}, false
+	}
+	var best *prefixNode
+	for i := 0; node != nil; i++ {
+		if node.prefix != nil {
+			best = node
+		}
+		if i == bits {
+			break
+		}
+		node = node.child[bit(ip, i)]
+	}
+	if best == nil {
+		return nil, net.IPNet{}, false
+	}
+	return append([]string{}, best.tags...), *best.prefix, true
+}
+
+// Covering prefixes of tree containing prefix or equal to it, from the shortest one
+func (t *PrefixTree) Covering(prefix net.IPNet) []PrefixMatch {
+	ip, bits := t.key(prefix)
+	node := t.root(prefix)
+	var res []PrefixMatch
+	for i := 0; node != nil; i++ {
+		if node.prefix != nil {
+			res = append(res, PrefixMatch{Prefix: *node.prefix, Tags: append([]string{}, node.tags...)})
+		}
+		if i == bits {
+			break
+		}
+		node = node.child[bit(ip, i)]
+	}
+	return res
+}
+
+// Walk calls fn for every prefix in address order, IPv4 first
+func (t *PrefixTree) Walk(fn func(PrefixMatch)) {
+	var walk func(n *prefixNode)
+	walk = func(n *prefixNode) {
+		if n == nil {
+			return
+		}
+		if n.prefix != nil {
+			fn(PrefixMatch{Prefix: *n.prefix, Tags: append([]string{}, n.tags...)})
+		}
+		walk(n.child[0])
+		walk(n.child[1])
+	}
+	walk(t.v4)
+	walk(t.v6)
+}
+
+func (t *PrefixTree) root(prefix net.IPNet) *prefixNode {
+	if len(prefix.Mask) == net.IPv4len {
+		return t.v4
+	}
+	return t.v6
+}
+
+// key address of family of mask and length of prefix
+func (t *PrefixTree) key(prefix net.IPNet) (net.IP, int) {
+	ones, _ := prefix.Mask.Size()
+	ip := prefix.IP.To16()
+	if len(prefix.Mask) == net.IPv4len {
+		ip = prefix.IP.To4()
+	}
+	return ip, ones
+}
+
+func bit(ip net.IP, i int) int {
+	// nolint: gomnd
+	return int(ip[i/8]>>(7-uint(i%8))) & 1
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package dnssdk
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNetworkMapping(t *testing.T) {
+	mapping := NetworkMappingRequest{Name: "office", Mapping: []MappingEntry{
+		{Tags: []string{"dc"}, CIDR4: []IPNet{mustParseCIDR("10.0.0.0/8"), mustParseCIDR("10.1.0.0/16")}},
+		{Tags: []string{"office"}, CIDR4: []IPNet{mustParseCIDR("10.1.2.0/24"), mustParseCIDR("192.0.2.0/24")}},
+		{Tags: []string{"vpn"}, CIDR4: []IPNet{mustParseCIDR("192.0.2.0/24")},
+			CIDR6: []IPNet{mustParseCIDR("::ffff:198.51.100.0/120"), mustParseCIDR("2001:db8::/32")}},
+		{Tags: []string{"vpn"}, CIDR6: []IPNet{mustParseCIDR("2001:db8::/32")}, CIDR4: []IPNet{mustParseCIDR("fd00::/8")}},
+		{Tags: []string{""}},
+	}}
+
+	type found struct {
+		severity Severity
+		kind     MappingIssueKind
+		entry    int
+		cidr     string
+		other    int
+	}
+	var got []found
+	for _, issue := range ValidateNetworkMapping(mapping) {
+		got = append(got, found{issue.Severity, issue.Kind, issue.Entry, issue.CIDR, issue.OtherEntry})
+	}
+	assert.Equal(t, []found{
+		{SeverityError, MappingDuplicate, 2, "192.0.2.0/24", 1},
+		{SeverityError, MappingFamily, 3, "fd00::/8", -1},
+		{SeverityError, MappingEmptyTags, 4, "", -1},
+		{SeverityWarning, MappingOverlap, 0, "10.1.0.0/16", 0},
+		{SeverityWarning, MappingIPv4Mapped, 2, "198.51.100.0/24", -1},
+		{SeverityWarning, MappingDuplicate, 3, "2001:db8::/32", 2},
+		{SeverityWarning, MappingEmptyEntry, 4, "", -1},
+		{SeverityInfo, MappingOverlap, 1, "10.1.2.0/24", 0},
+	}, got)
+
+	err := mapping.Validate()
+	var mappingErr *MappingError
+	require.True(t, errors.As(err, &mappingErr))
+	assert.Len(t, mappingErr.Issues, 3)
+	assert.Contains(t, err.Error(), "192.0.2.0/24 has conflicting tags in entry 1")
+
+	assert.NoError(t, NetworkMappingRequest{Name: "ok", Mapping: mappingOf("ok", "10.0.0.0/8", "2001:db8::/32")}.Validate())
+}
+
+func TestPrefixTree(t *testing.T) {
+	mapping := NetworkMappingRequest{Mapping: []MappingEntry{
+		{Tags: []string{"dc"}, CIDR4: []IPNet{mustParseCIDR("10.0.0.0/8")}},
+		{Tags: []string{"office", "eu"}, CIDR4: []IPNet{mustParseCIDR("10.1.2.0/24")}},
+		{Tags: []string{"default"}, CIDR4: []IPNet{mustParseCIDR("0.0.0.0/0")}},
+		{Tags: []string{"v6"}, CIDR6: []IPNet{mustParseCIDR("2001:db8::/32")}},
+		{Tags: []string{"host"}, CIDR6: []IPNet{mustParseCIDR("2001:db8::1/128")}},
+	}}
+	tree := NewMappingTree(mapping.Mapping)
+	assert.Equal(t, 5, tree.Len())
+
+	tests := map[string][]string{
+		"10.1.2.3":      {"office", "eu"},
+		"10.1.3.3":      {"dc"},
+		"192.0.2.1":     {"default"},
+		"2001:db8::1":   {"host"},
+		"2001:db8::2":   {"v6"},
+		"2001:db9::1":   nil,
+		"::ffff:10.1.2": nil,
+	}
+	for ip, want := range tests {
+		assert.Equal(t, want, mapping.TagsFor(net.ParseIP(ip)), ip)
+	}
+
+	// IPv4 address in 16 bytes form
+	tags, prefix, ok := tree.Lookup(net.ParseIP("10.200.0.1").To16())
+	require.True(t, ok)
+	assert.Equal(t, []string{"dc"}, tags)
+	assert.Equal(t, "10.0.0.0/8", prefix.String())
+
+	covering := tree.Covering(mustParseCIDR("10.1.2.128/25").IPNet)
+	require.Len(t, covering, 3)
+	assert.Equal(t, "0.0.0.0/0", covering[0].Prefix.String())
+	assert.Equal(t, "10.1.2.0/24", covering[2].Prefix.String())
+
+	var walked []string
+	tree.Walk(func(m PrefixMatch) { walked = append(walked, m.Prefix.String()) })
+	assert.Equal(t, []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.2.0/24", "2001:db8::/32", "2001:db8::1/128"}, walked)
+
+	tree.Insert(mustParseCIDR("10.0.0.0/8").IPNet, "dc", "core")
+	tags, _, _ = tree.Lookup(net.ParseIP("10.9.9.9"))
+	assert.Equal(t, []string{"dc", "core"}, tags)
+	assert.Equal(t, 5, tree.Len())
+}
@@ -0,0 +1,314 @@
+package dnssdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+const networkMappingsPageSize = 100
+
+// NetworkMappingAction of sync plan
+type NetworkMappingAction string
+
+// actions of sync plan
+const (
+	NetworkMappingCreate NetworkMappingAction = "create"
+	NetworkMappingUpdate NetworkMappingAction = "update"
+	NetworkMappingDelete NetworkMappingAction = "delete"
+)
+
+// NetworkMappingChange planned change of network mapping, ID is empty for created mapping
+type NetworkMappingChange struct {
+	Action  NetworkMappingAction
+	Name    string
+	ID      uint64
+	Mapping []MappingEntry
+}
+
+// NetworkMappingsPlan changes making account mappings match desired ones
+type NetworkMappingsPlan struct {
+	Changes   []NetworkMappingChange
+	Unchanged []string
+}
+
+// Empty is true when account mappings match desired ones
+func (p NetworkMappingsPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// SyncNetworkMappingsOptions of SyncNetworkMappings
+type SyncNetworkMappingsOptions struct {
+	// DryRun returns plan without applying it
+	DryRun bool
+	// Prune deletes mappings of account missing in desired ones
+	Prune bool
+}
+
+// UpsertNetworkMapping updates mapping of the same name or creates it, returns id of mapping.
+// Mapping equal to existing one is not updated.
+func (c *Client) UpsertNetworkMapping(ctx context.Context, mapping NetworkMappingRequest) (uint64, error) {
+	current, err := c.GetNetworkMappingByName(ctx, mapping.Name)
+	switch {
+	case err == nil:
+		if sameMappingEntries(current.Mapping, mapping.Mapping) {
+			return current.ID, nil
+		}
+		if err = c.UpdateNetworkMapping(ctx, current.ID, mapping); err != nil {
+			return 0, fmt.Errorf("update network mapping %s: %w", mapping.Name, err)
+		}
+		return current.ID, nil
+	case isNotFound(err):
+		id, errCreate := c.CreateNetworkMapping(ctx, mapping)
+		if errCreate != nil {
+			return 0, fmt.Errorf("create network mapping %s: %w", mapping.Name, errCreate)
+		}
+		return id, nil
+	}
+	return 0, fmt.Errorf("get network mapping %s: %w", mapping.Name, err)
+}
+
+// PlanNetworkMappings compares desired mappings with all mappings of account
+func (c *Client) PlanNetworkMappings(ctx context.Context, desired []NetworkMappingRequest,
+	opts SyncNetworkMappingsOptions) (NetworkMappingsPlan, error) {
+	want := map[string]NetworkMappingRequest{}
+	for _, m := range desired {
+		if _, dup := want[m.Name]; dup {
+			// nolint: goerr113
+			return NetworkMappingsPlan{}, fmt.Errorf("network mapping %s is desired twice", m.Name)
+		}
+		want[m.Name] = m
+	}
+	current, err := c.AllNetworkMappings(ctx, NetworkMappingsParams{})
+	if err != nil {
+		return NetworkMappingsPlan{}, err
+	}
+
+	plan := NetworkMappingsPlan{}
+	seen := map[string]bool{}
+	for _, m := range current {
+		seen[m.Name] = true
+		desiredMapping, ok := want[m.Name]
+		switch {
+		case !ok && opts.Prune:
+			plan.Changes = append(plan.Changes, NetworkMappingChange{Action: NetworkMappingDelete, Name: m.Name, ID: m.ID})
+		case !ok:
+		case sameMappingEntries(m.Mapping, desiredMapping.Mapping):
+			plan.Unchanged = append(plan.Unchanged, m.Name)
+		default:
+			plan.Changes = append(plan.Changes, NetworkMappingChange{Action: NetworkMappingUpdate, Name: m.Name,
+				ID: m.ID, Mapping: desiredMapping.Mapping})
+		}
+	}
+	for _, m := range desired {
+		if !seen[m.Name] {
+			plan.Changes = append(plan.Changes, NetworkMappingChange{Action: NetworkMappingCreate, Name: m.Name,
+				Mapping: m.Mapping})
+		}
+	}
+
+	// deletes go last, mapping could be renamed with the same content
+	order := map[NetworkMappingAction]int{NetworkMappingCreate: 0, NetworkMappingUpdate: 1, NetworkMappingDelete: 2}
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		if order[plan.Changes[i].Action] != order[plan.Changes[j].Action] {
+			return order[plan.Changes[i].Action] < order[plan.Changes[j].Action]
+		}
+		return plan.Changes[i].Name < plan.Changes[j].Name
+	})
+	sort.Strings(plan.Unchanged)
+	return plan, nil
+}
+
+// SyncNetworkMappings makes mappings of account match desired ones and returns applied plan.
+// Changes are applied in plan order, the first failed one stops sync.
+func (c *Client) SyncNetworkMappings(ctx context.Context, desired []NetworkMappingRequest,
+	opts SyncNetworkMappingsOptions) (NetworkMappingsPlan, error) {
+	plan, err := c.PlanNetworkMappings(ctx, desired, opts)
+	if err != nil || opts.DryRun {
+		return plan, err
+	}
+	for i, change := range plan.Changes {
+		mapping := NetworkMappingRequest{Name: change.Name, Mapping: change.Mapping}
+		switch change.Action {
+		case NetworkMappingCreate:
+			plan.Changes[i].ID, err = c.CreateNetworkMapping(ctx, mapping)
+		case NetworkMappingUpdate:
+			err = c.UpdateNetworkMapping(ctx, change.ID, mapping)
+		case NetworkMappingDelete:
+			err = c.DeleteNetworkMapping(ctx, change.ID)
+		}
+		if err != nil {
+			return plan, fmt.Errorf("%s network mapping %s: %w", change.Action, change.Name, err)
+		}
+	}
+	return plan, nil
+}
+
+// NetworkMappingFilter client-side predicate of network mappings
+type NetworkMappingFilter func(mapping NetworkMappingResponse) bool
+
+// NetworkMappingWithName matches mappings with name containing substr
+func NetworkMappingWithName(substr string) NetworkMappingFilter {
+	return func(mapping NetworkMappingResponse) bool {
+		return strings.Contains(mapping.Name, substr)
+	}
+}
+
+// NetworkMappingWithTags matches mappings having any of tags
+func NetworkMappingWithTags(tags ...string) NetworkMappingFilter {
+	return func(mapping NetworkMappingResponse) bool {
+		for _, entry := range mapping.Mapping {
+			for _, tag := range entry.Tags {
+				if containsString(tags, tag) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// NetworkMappingContains matches mappings with a prefix containing prefix or equal to it
+func NetworkMappingContains(prefix net.IPNet) NetworkMappingFilter {
+	return func(mapping NetworkMappingResponse) bool {
+		return len(NewMappingTree(mapping.Mapping).Covering(prefix)) > 0
+	}
+}
+
+// NetworkMappingContainsIP matches mappings with a prefix containing ip
+func NetworkMappingContainsIP(ip net.IP) NetworkMappingFilter {
+	return func(mapping NetworkMappingResponse) bool {
+		_, _, ok := NewMappingTree(mapping.Mapping).Lookup(ip)
+		return ok
+	}
+}
+
+// NetworkMappingIterator pages through network mappings, usage:
+//
+//	it := client.NetworkMappingsIterator(ctx, params)
+//	for it.Next() {
+//		mapping := it.NetworkMapping()
+//	}
+//	err := it.Err()
+type NetworkMappingIterator struct {
+	ctx     context.Context
+	api     NetworkMappingsAPI
+	params  NetworkMappingsParams
+	filters []NetworkMappingFilter
+	page    []NetworkMappingResponse
+	current NetworkMappingResponse
+	done    bool
+	err     error
+}
+
+// NewNetworkMappingIterator over mappings of api from Offset of params in order of params,
+// pages are requested lazily, mappings not matching every filter are skipped
+func NewNetworkMappingIterator(ctx context.Context, api NetworkMappingsAPI, params NetworkMappingsParams,
+	filters ...NetworkMappingFilter) *NetworkMappingIterator {
+	if params.Limit == 0 {
+		params.Limit = networkMappingsPageSize
+	}
+	return &NetworkMappingIterator{ctx: ctx, api: api, params: params, filters: filters}
+}
+
+// NetworkMappingsIterator over all mappings of account
+func (c *Client) NetworkMappingsIterator(ctx context.Context, params NetworkMappingsParams,
+	filters ...NetworkMappingFilter) *NetworkMappingIterator {
+	return NewNetworkMappingIterator(ctx, c, params, filters...)
+}
+
+// Next advances to the next mapping, false when mappings are over or request failed
+func (it *NetworkMappingIterator) Next() bool {
+	for {
+		for len(it.page) > 0 {
+			mapping := it.page[0]
+			it.page = it.page[1:]
+			if it.match(mapping) {
+				it.current = mapping
+				return true
+			}
+		}
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+}
+
+// NetworkMapping current mapping of iterator
+func (it *NetworkMappingIterator) NetworkMapping() NetworkMappingResponse {
+	return it.current
+}
+
+// Err of failed page request
+func (it *NetworkMappingIterator) Err() error {
+	return it.err
+}
+
+func (it *NetworkMappingIterator) fetch() {
+	page, err := it.api.ListNetworkMappings(it.ctx, it.params)
+	if err != nil {
+		it.err = fmt.Errorf("list network mappings: %w", err)
+		return
+	}
+	it.page = page.NetworkMappings
+	it.params.Offset += uint64(len(page.NetworkMappings))
+	if len(page.NetworkMappings) == 0 || it.params.Offset >= uint64(page.TotalAmount) {
+		it.done = true
+	}
+}
+
+func (it *NetworkMappingIterator) match(mapping NetworkMappingResponse) bool {
+	for _, filter := range it.filters {
+		if !filter(mapping) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllNetworkMappings collects mappings of NetworkMappingsIterator
+func (c *Client) AllNetworkMappings(ctx context.Context, params NetworkMappingsParams,
+	filters ...NetworkMappingFilter) ([]NetworkMappingResponse, error) {
+	var res []NetworkMappingResponse
+	it := c.NetworkMappingsIterator(ctx, params, filters...)
+	for it.Next() {
+		res = append(res, it.NetworkMapping())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// sameMappingEntries ignores order of entries, cidrs and tags
+func sameMappingEntries(a, b []MappingEntry) bool {
+	return normalizedMapping(a) == normalizedMapping(b)
+}
+
+func normalizedMapping(entries []MappingEntry) string {
+	type entry struct {
+		CIDR4, CIDR6, Tags []string
+	}
+	sorted := func(nets []IPNet) []string {
+		res := make([]string, 0, len(nets))
+		for _, n := range nets {
+			res = append(res, n.String())
+		}
+		sort.Strings(res)
+		return res
+	}
+	res := make([]string, 0, len(entries))
+	for _, e := range entries {
+		tags := append([]string{}, e.Tags...)
+		sort.Strings(tags)
+		bs, _ := json.Marshal(entry{CIDR4: sorted(e.CIDR4), CIDR6: sorted(e.CIDR6), Tags: tags})
+		res = append(res, string(bs))
+	}
+	sort.Strings(res)
+	bs, _ := json.Marshal(res)
+	return string(bs)
+}
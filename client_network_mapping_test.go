@@ -0,0 +1,295 @@
+package dnssdk
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mappingServer in-memory network mappings with paging of list
+type mappingServer struct {
+	mu       sync.Mutex
+	lastID   uint64
+	mappings map[uint64]NetworkMappingResponse
+	// requests method and path of every request
+	requests []string
+}
+
+func newMappingServer(t *testing.T, mappings ...NetworkMappingRequest) (*mappingServer, *Client) {
+	t.Helper()
+
+	s := &mappingServer{mappings: map[uint64]NetworkMappingResponse{}}
+	for _, m := range mappings {
+		s.lastID++
+		s.mappings[s.lastID] = NetworkMappingResponse{ID: s.lastID, Name: m.Name, Mapping: m.Mapping}
+	}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	client := NewClient(PermanentAPIKeyAuth(testToken))
+	client.BaseURL, _ = url.Parse(server.URL)
+	return s, client
+}
+
+func (s *mappingServer) writes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []string
+	for _, r := range s.requests {
+		if !strings.HasPrefix(r, http.MethodGet) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func (s *mappingServer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req.Method+" "+req.URL.Path)
+
+	notFound := func() {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"error":"not found"}`))
+	}
+	key := strings.TrimPrefix(req.URL.Path, "/v2/network-mappings")
+	key = strings.TrimPrefix(key, "/")
+
+	if key == "" {
+		switch req.Method {
+		case http.MethodGet:
+			s.list(rw, req)
+		case http.MethodPost:
+			var m NetworkMappingRequest
+			_ = json.NewDecoder(req.Body).Decode(&m)
+			s.lastID++
+			s.mappings[s.lastID] = NetworkMappingResponse{ID: s.lastID, Name: m.Name, Mapping: m.Mapping}
+			handleJSONResponse(CreateNetworkMappingResponse{ID: s.lastID})(rw, req)
+		}
+		return
+	}
+
+	var (
+		found NetworkMappingResponse
+		ok    bool
+	)
+	if id, err := strconv.ParseUint(key, 10, 64); err == nil {
+		found, ok = s.mappings[id]
+	} else {
+		for _, m := range s.mappings {
+			if m.Name == key {
+				found, ok = m, true
+			}
+		}
+	}
+	if !ok {
+		notFound()
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		handleJSONResponse(map[string]NetworkMappingResponse{"network_mapping": found})(rw, req)
+	case http.MethodPut:
+		var m NetworkMappingRequest
+		_ = json.NewDecoder(req.Body).Decode(&m)
+		s.mappings[found.ID] = NetworkMappingResponse{ID: found.ID, Name: m.Name, Mapping: m.Mapping}
+	case http.MethodDelete:
+		delete(s.mappings, found.ID)
+	}
+}
+
+func (s *mappingServer) list(rw http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+	all := make([]NetworkMappingResponse, 0, len(s.mappings))
+	for _, m := range s.mappings {
+		all = append(all, m)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		less := all[i].ID < all[j].ID
+		if q.Get("order_by") == "name" {
+			less = all[i].Name < all[j].Name
+		}
+		if q.Get("order_direction") == "desc" {
+			return !less
+		}
+		return less
+	})
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	// small page limit of server
+	if limit == 0 || limit > 2 {
+		limit = 2
+	}
+	page := []NetworkMappingResponse{}
+	for i := offset; i < len(all) && i < offset+limit; i++ {
+		page = append(page, all[i])
+	}
+	handleJSONResponse(ListNetworkMappingResponse{NetworkMappings: page, TotalAmount: len(all)})(rw, req)
+}
+
+func mappingOf(tag string, cidrs ...string) []MappingEntry {
+	entry := MappingEntry{Tags: []string{tag}}
+	for _, c := range cidrs {
+		if strings.Contains(c, ":") {
+			entry.CIDR6 = append(entry.CIDR6, mustParseCIDR(c))
+		} else {
+			entry.CIDR4 = append(entry.CIDR4, mustParseCIDR(c))
+		}
+	}
+	return []MappingEntry{entry}
+}
+
+func TestClient_UpsertNetworkMapping(t *testing.T) {
+	server, client := newMappingServer(t,
+		NetworkMappingRequest{Name: "office", Mapping: mappingOf("office", "10.0.0.0/8")})
+	ctx := context.Background()
+
+	id, err := client.UpsertNetworkMapping(ctx, NetworkMappingRequest{Name: "office",
+		Mapping: mappingOf("office", "10.0.0.0/8", "192.168.0.0/16")})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), id)
+
+	// the same mapping is not updated
+	_, err = client.UpsertNetworkMapping(ctx, NetworkMappingRequest{Name: "office",
+		Mapping: mappingOf("office", "192.168.0.0/16", "10.0.0.0/8")})
+	require.NoError(t, err)
+
+	id, err = client.UpsertNetworkMapping(ctx, NetworkMappingRequest{Name: "vpn", Mapping: mappingOf("vpn", "fd00::/8")})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), id)
+
+	assert.Equal(t, []string{"PUT /v2/network-mappings/1", "POST /v2/network-mappings"}, server.writes())
+}
+
+func TestClient_SyncNetworkMappings(t *testing.T) {
+	server, client := newMappingServer(t,
+		NetworkMappingRequest{Name: "office", Mapping: mappingOf("office", "10.0.0.0/8")},
+		NetworkMappingRequest{Name: "old", Mapping: mappingOf("old", "172.16.0.0/12")},
+		NetworkMappingRequest{Name: "same", Mapping: mappingOf("same", "192.0.2.0/24")},
+		NetworkMappingRequest{Name: "unmanaged", Mapping: mappingOf("x", "198.51.100.0/24")},
+		NetworkMappingRequest{Name: "vpn", Mapping: mappingOf("vpn", "fd00::/8")},
+	)
+	ctx := context.Background()
+	desired := []NetworkMappingRequest{
+		{Name: "same", Mapping: mappingOf("same", "192.0.2.0/24")},
+		{Name: "office", Mapping: mappingOf("office", "10.0.0.0/16")},
+		{Name: "new", Mapping: mappingOf("new", "203.0.113.0/24")},
+		{Name: "vpn", Mapping: mappingOf("vpn", "fd00::/8")},
+	}
+
+	plan, err := client.SyncNetworkMappings(ctx, desired, SyncNetworkMappingsOptions{DryRun: true, Prune: true})
+	require.NoError(t, err)
+	type step struct {
+		action NetworkMappingAction
+		name   string
+		id     uint64
+	}
+	var steps []step
+	for _, c := range plan.Changes {
+		steps = append(steps, step{c.Action, c.Name, c.ID})
+	}
+	assert.Equal(t, []step{
+		{NetworkMappingCreate, "new", 0},
+		{NetworkMappingUpdate, "office", 1},
+		{NetworkMappingDelete, "old", 2},
+		{NetworkMappingDelete, "unmanaged", 4},
+	}, steps, "all 3 pages are listed")
+	assert.Equal(t, []string{"same", "vpn"}, plan.Unchanged)
+	assert.Empty(t, server.writes(), "dry run")
+
+	// without prune unmanaged mappings are kept
+	plan, err = client.SyncNetworkMappings(ctx, desired, SyncNetworkMappingsOptions{})
+	require.NoError(t, err)
+	assert.Len(t, plan.Changes, 2)
+	assert.Equal(t, uint64(6), plan.Changes[0].ID, "id of created mapping")
+	assert.Equal(t, []string{"POST /v2/network-mappings", "PUT /v2/network-mappings/1"}, server.writes())
+
+	plan, err = client.SyncNetworkMappings(ctx, desired, SyncNetworkMappingsOptions{})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+
+	_, err = client.SyncNetworkMappings(ctx, append(desired, desired[0]), SyncNetworkMappingsOptions{})
+	assert.ErrorContains(t, err, "same is desired twice")
+}
+
+func TestClient_NetworkMappingsIterator(t *testing.T) {
+	server, client := newMappingServer(t,
+		NetworkMappingRequest{Name: "office-eu", Mapping: mappingOf("eu", "10.0.0.0/8")},
+		NetworkMappingRequest{Name: "office-us", Mapping: mappingOf("us", "172.16.0.0/12")},
+		NetworkMappingRequest{Name: "vpn", Mapping: mappingOf("vpn", "fd00::/8")},
+		NetworkMappingRequest{Name: "office-asia", Mapping: mappingOf("asia", "10.1.0.0/16")},
+		NetworkMappingRequest{Name: "edge", Mapping: mappingOf("eu", "192.0.2.0/24")},
+	)
+	ctx := context.Background()
+
+	// pages are requested lazily
+	it := client.NetworkMappingsIterator(ctx, NetworkMappingsParams{OrderBy: "name", OrderDirection: "desc"})
+	require.True(t, it.Next())
+	assert.Equal(t, "vpn", it.NetworkMapping().Name)
+	assert.Len(t, server.requests, 1)
+
+	names := func(mappings []NetworkMappingResponse) []string {
+		var res []string
+		for _, m := range mappings {
+			res = append(res, m.Name)
+		}
+		return res
+	}
+	all, err := client.AllNetworkMappings(ctx, NetworkMappingsParams{OrderBy: "name"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edge", "office-asia", "office-eu", "office-us", "vpn"}, names(all))
+
+	all, err = client.AllNetworkMappings(ctx, NetworkMappingsParams{OrderBy: "name"},
+		NetworkMappingWithName("office"), NetworkMappingWithTags("eu", "asia"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"office-asia", "office-eu"}, names(all))
+
+	all, err = client.AllNetworkMappings(ctx, NetworkMappingsParams{},
+		NetworkMappingContains(mustParseCIDR("10.1.2.0/24").IPNet))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"office-eu", "office-asia"}, names(all))
+
+	all, err = client.AllNetworkMappings(ctx, NetworkMappingsParams{}, NetworkMappingContainsIP(net.ParseIP("fd00::1")),
+		NetworkMappingWithTags("vpn"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vpn"}, names(all))
+
+	all, err = client.AllNetworkMappings(ctx, NetworkMappingsParams{}, NetworkMappingWithName("missing"))
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestClient_NetworkMappingsIterator_error(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.HandleFunc("/v2/network-mappings", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("offset") == "" {
+			handleJSONResponse(ListNetworkMappingResponse{TotalAmount: 2,
+				NetworkMappings: []NetworkMappingResponse{{ID: 1, Name: "first"}}})(rw, req)
+			return
+		}
+		rw.WriteHeader(http.StatusInternalServerError)
+		_, _ = rw.Write([]byte(`{"error":"boom"}`))
+	})
+
+	it := NewNetworkMappingIterator(context.Background(), client, NetworkMappingsParams{})
+	require.True(t, it.Next())
+	assert.Equal(t, "first", it.NetworkMapping().Name)
+	assert.False(t, it.Next())
+	assert.ErrorContains(t, it.Err(), "list network mappings")
+
+	_, err := client.AllNetworkMappings(context.Background(), NetworkMappingsParams{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,160 @@
+package dnssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PartialOptions for AllZonesWithRecordsPartial
+type PartialOptions struct {
+	// SkipNotFound ignores zones deleted during the scan
+	SkipNotFound bool
+	// Retries of failed zone request, 404 and other 4xx errors are not retried
+	Retries int
+	// Backoff before the first retry, doubled for every next one, 500ms when empty
+	Backoff time.Duration
+	// Workers amount of parallel requests, 10 when empty
+	Workers int
+}
+
+// ZoneError failed zone of AllZonesWithRecordsPartial
+type ZoneError struct {
+	Zone string
+	Err  error
+}
+
+// Error implementation
+func (e ZoneError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Zone, e.Err)
+}
+
+// Unwrap implementation
+func (e ZoneError) Unwrap() error {
+	return e.Err
+}
+
+// ZonesError lists zones failed in AllZonesWithRecordsPartial
+type ZonesError struct {
+	Zones []ZoneError
+}
+
+// Error implementation
+func (e *ZonesError) Error() string {
+	msgs := make([]string, len(e.Zones))
+	for i, z := range e.Zones {
+		msgs[i] = z.Error()
+	}
+	return fmt.Sprintf("%d zones failed: %s", len(e.Zones), strings.Join(msgs, "; "))
+}
+
+// Is matches target against errors of every failed zone
+func (e *ZonesError) Is(target error) bool {
+	return errorsIs(e.errs(), target)
+}
+
+// As finds the first error of failed zones matching target
+func (e *ZonesError) As(target any) bool {
+	return errorsAs(e.errs(), target)
+}
+
+func (e *ZonesError) errs() []error {
+	errs := make([]error, len(e.Zones))
+	for i, z := range e.Zones {
+		errs[i] = z
+	}
+	return errs
+}
+
+// errorsIs matches target against every error, go 1.18 has no multi-error Unwrap
+func errorsIs(errs []error, target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorsAs finds the first error matching target
+func errorsAs(errs []error, target any) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllZonesWithRecordsPartial gets all zones with records information.
+// Unlike AllZonesWithRecords it returns every fetched zone together with *ZonesError describing failed ones.
+func (c *Client) AllZonesWithRecordsPartial(ctx context.Context, nameFilters []string, opts PartialOptions) ([]Zone, error) {
+	zones, err := c.AllZones(ctx, nameFilters)
+	if err != nil {
+		return nil, fmt.Errorf("all zones: %w", err)
+	}
+
+	fetched := make([]Zone, len(zones))
+	res := runBulk(ctx, len(zones), BulkOptions{Workers: opts.Workers}, func(ctx context.Context, i int) BulkItemResult {
+		zone, errGet := c.zoneWithRetry(ctx, zones[i].Name, opts)
+		fetched[i] = zone
+		return BulkItemResult{Item: zones[i].Name, Err: errGet}
+	})
+
+	result := make([]Zone, 0, len(zones))
+	zonesErr := &ZonesError{}
+	for i, item := range res.Items {
+		if item.Err == nil {
+			result = append(result, fetched[i])
+			continue
+		}
+		if opts.SkipNotFound && isNotFound(item.Err) {
+			continue
+		}
+		zonesErr.Zones = append(zonesErr.Zones, ZoneError{Zone: item.Item, Err: item.Err})
+	}
+	if len(zonesErr.Zones) > 0 {
+		return result, zonesErr
+	}
+
+	return result, nil
+}
+
+func (c *Client) zoneWithRetry(ctx context.Context, name string, opts PartialOptions) (Zone, error) {
+	backoff := opts.Backoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	for attempt := 0; ; attempt++ {
+		zone, err := c.Zone(ctx, name)
+		if err == nil || attempt >= opts.Retries || !retryable(err) {
+			return zone, err
+		}
+		select {
+		case <-ctx.Done():
+			return Zone{}, fmt.Errorf("retry %s: %w", name, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isNotFound(err error) bool {
+	errAPI := new(APIError)
+	return errors.As(err, errAPI) && errAPI.StatusCode == http.StatusNotFound
+}
+
+// retryable for 429, 5xx and transport errors
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	errAPI := new(APIError)
+	if !errors.As(err, errAPI) {
+		return true
+	}
+	return retryableStatus(errAPI.StatusCode)
+}
@@ -0,0 +1,78 @@
+package dnssdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_AllZonesWithRecordsPartial(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/zones", validationHandler{
+		method: http.MethodGet,
+		next: handleJSONResponse(ListZones{Zones: []Zone{
+			{Name: "ok.com"}, {Name: "deleted.com"}, {Name: "flaky.com"}, {Name: "broken.com"},
+		}}),
+	})
+	mux.Handle("/v2/zones/ok.com", validationHandler{
+		method: http.MethodGet,
+		next:   handleJSONResponse(Zone{Name: "ok.com", Serial: 1}),
+	})
+	var flakyCalls, brokenCalls int32
+	mux.Handle("/v2/zones/flaky.com", validationHandler{
+		method: http.MethodGet,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&flakyCalls, 1) == 1 {
+				handleAPIError()(rw, req)
+				return
+			}
+			handleJSONResponse(Zone{Name: "flaky.com", Serial: 2})(rw, req)
+		}),
+	})
+	mux.Handle("/v2/zones/broken.com", validationHandler{
+		method: http.MethodGet,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&brokenCalls, 1)
+			http.Error(rw, "bad request", http.StatusBadRequest)
+		}),
+	})
+
+	zones, err := client.AllZonesWithRecordsPartial(context.Background(), nil,
+		PartialOptions{SkipNotFound: true, Retries: 2, Backoff: time.Millisecond})
+
+	assert.Equal(t, []Zone{{Name: "ok.com", Serial: 1}, {Name: "flaky.com", Serial: 2}}, zones)
+	zonesErr := new(ZonesError)
+	require.True(t, errors.As(err, &zonesErr))
+	require.Len(t, zonesErr.Zones, 1)
+	assert.Equal(t, "broken.com", zonesErr.Zones[0].Zone)
+	var apiErr APIError
+	require.True(t, errors.As(err, &apiErr), "errors of zones are matched")
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.True(t, errors.Is(err, apiErr))
+	assert.Equal(t, int32(1), brokenCalls, "4xx is not retried")
+	assert.Equal(t, int32(2), flakyCalls)
+}
+
+func TestClient_AllZonesWithRecordsPartial_notFound(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/zones", validationHandler{
+		method: http.MethodGet,
+		next:   handleJSONResponse(ListZones{Zones: []Zone{{Name: "deleted.com"}}}),
+	})
+
+	zones, err := client.AllZonesWithRecordsPartial(context.Background(), nil, PartialOptions{})
+	assert.Empty(t, zones)
+	require.EqualError(t, err, "1 zones failed: deleted.com: get zone deleted.com: 404: 404 page not found\n")
+
+	zones, err = client.AllZonesWithRecordsPartial(context.Background(), nil, PartialOptions{SkipNotFound: true})
+	require.NoError(t, err)
+	assert.Empty(t, zones)
+}
@@ -0,0 +1,347 @@
+package dnssdk
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRecord content of record does not match its type
+var ErrInvalidRecord = errors.New("invalid record")
+
+// tlsaDigestSizes bytes of data by TLSA matching type, full certificate has any size
+var tlsaDigestSizes = map[int64]int{1: 32, 2: 64}
+
+// sshfpDigestSizes bytes of fingerprint by SSHFP fingerprint type
+var sshfpDigestSizes = map[int64]int{1: 20, 2: 32}
+
+// sshfpAlgorithms RSA, DSA, ECDSA, Ed25519 and Ed448
+var sshfpAlgorithms = map[int64]bool{1: true, 2: true, 3: true, 4: true, 6: true}
+
+// mnemonics of RFC 7218 and RFC 4398
+var (
+	tlsaUsages    = map[string]int64{"PKIXTA": 0, "PKIXEE": 1, "DANETA": 2, "DANEEE": 3}
+	tlsaSelectors = map[string]int64{"CERT": 0, "SPKI": 1}
+	tlsaMatchings = map[string]int64{"FULL": 0, "SHA2256": 1, "SHA2512": 2}
+	certTypes     = map[string]int64{"PKIX": 1, "SPKI": 2, "PGP": 3, "IPKIX": 4, "ISPKI": 5, "IPGP": 6,
+		"ACPKIX": 7, "IACPKIX": 8, "URI": 253, "OID": 254}
+)
+
+// RecordTypeTLSA as type of record: usage selector matching-type data,
+// data is hex and could be split by spaces, mnemonics like DANE-EE SPKI SHA2-256 are accepted
+type RecordTypeTLSA string
+
+// ToContent convertor, nil for invalid value
+func (tlsa RecordTypeTLSA) ToContent() []any {
+	content, _ := tlsa.parse()
+	return content
+}
+
+// Validate value, data length must match SHA2-256 and SHA2-512 matching types
+func (tlsa RecordTypeTLSA) Validate() error {
+	_, err := tlsa.parse()
+	return err
+}
+
+func (tlsa RecordTypeTLSA) parse() ([]any, error) {
+	parts := strings.Fields(string(tlsa))
+	// nolint: gomnd
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("%w: tlsa %q: want usage selector matching-type data", ErrInvalidRecord, tlsa)
+	}
+	usage, err := parseRecordField(parts[0], "tlsa usage", 3, tlsaUsages)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := parseRecordField(parts[1], "tlsa selector", 1, tlsaSelectors)
+	if err != nil {
+		return nil, err
+	}
+	// nolint: gomnd
+	matching, err := parseRecordField(parts[2], "tlsa matching type", 2, tlsaMatchings)
+	if err != nil {
+		return nil, err
+	}
+	data, err := parseRecordHex(strings.Join(parts[3:], ""), "tlsa data", tlsaDigestSizes[matching])
+	if err != nil {
+		return nil, err
+	}
+	return []any{usage, selector, matching, data}, nil
+}
+
+// RecordTypeSSHFP as type of record: algorithm fingerprint-type fingerprint
+type RecordTypeSSHFP string
+
+// ToContent convertor, nil for invalid value
+func (sshfp RecordTypeSSHFP) ToContent() []any {
+	content, _ := sshfp.parse()
+	return content
+}
+
+// Validate value, fingerprint length must match SHA-1 and SHA-256 types
+func (sshfp RecordTypeSSHFP) Validate() error {
+	_, err := sshfp.parse()
+	return err
+}
+
+func (sshfp RecordTypeSSHFP) parse() ([]any, error) {
+	parts := strings.Fields(string(sshfp))
+	// nolint: gomnd
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("%w: sshfp %q: want algorithm type fingerprint", ErrInvalidRecord, sshfp)
+	}
+	algorithm, err := parseRecordField(parts[0], "sshfp algorithm", math.MaxUint8, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !sshfpAlgorithms[algorithm] {
+		return nil, fmt.Errorf("%w: sshfp algorithm %d is unknown", ErrInvalidRecord, algorithm)
+	}
+	fpType, err := parseRecordField(parts[1], "sshfp type", math.MaxUint8, nil)
+	if err != nil {
+		return nil, err
+	}
+	size, ok := sshfpDigestSizes[fpType]
+	if !ok {
+		return nil, fmt.Errorf("%w: sshfp type %d is unknown", ErrInvalidRecord, fpType)
+	}
+	fp, err := parseRecordHex(strings.Join(parts[2:], ""), "sshfp fingerprint", size)
+	if err != nil {
+		return nil, err
+	}
+	return []any{algorithm, fpType, fp}, nil
+}
+
+// RecordTypeNAPTR as type of record: order preference flags service regexp replacement.
+// Quoted value is zone file presentation with escapes,
+// unquoted value like ContentToString is split by single spaces to keep empty fields.
+type RecordTypeNAPTR string
+
+// ToContent convertor, nil for invalid value
+func (naptr RecordTypeNAPTR) ToContent() []any {
+	content, _ := naptr.parse()
+	return content
+}
+
+// Validate value: flags, syntax of regexp and exclusive regexp and replacement
+func (naptr RecordTypeNAPTR) Validate() error {
+	_, err := naptr.parse()
+	return err
+}
+
+func (naptr RecordTypeNAPTR) parse() ([]any, error) {
+	fields, err := naptrFields(string(naptr))
+	if err != nil {
+		return nil, fmt.Errorf("%w: naptr: %v", ErrInvalidRecord, err)
+	}
+	// nolint: gomnd
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("%w: naptr %q: want order preference flags service regexp replacement",
+			ErrInvalidRecord, naptr)
+	}
+	order, err := parseRecordField(fields[0], "naptr order", math.MaxUint16, nil)
+	if err != nil {
+		return nil, err
+	}
+	preference, err := parseRecordField(fields[1], "naptr preference", math.MaxUint16, nil)
+	if err != nil {
+		return nil, err
+	}
+	flags, service, expr, replacement := fields[2], fields[3], fields[4], fields[5]
+	if err = validateNAPTRFlags(flags); err != nil {
+		return nil, err
+	}
+	if expr != "" {
+		if err = validateNAPTRRegexp(expr); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case replacement == "":
+		return nil, fmt.Errorf("%w: naptr replacement is empty, use . with regexp", ErrInvalidRecord)
+	case expr != "" && replacement != ".":
+		return nil, fmt.Errorf("%w: naptr regexp and replacement are exclusive", ErrInvalidRecord)
+	case strings.Contains(strings.ToUpper(flags), "U") && expr == "":
+		return nil, fmt.Errorf("%w: naptr flag U requires regexp", ErrInvalidRecord)
+	}
+	return []any{order, preference, flags, service, expr, replacement}, nil
+}
+
+func naptrFields(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, `"`) {
+		return ParseTXTStrings(value)
+	}
+	// nolint: gomnd
+	if fields := strings.Split(value, " "); len(fields) == 6 {
+		return fields, nil
+	}
+	return strings.Fields(value), nil
+}
+
+// validateNAPTRFlags alphanumeric flags with at most one of terminal S, A and U
+func validateNAPTRFlags(flags string) error {
+	terminal := 0
+	for _, c := range strings.ToUpper(flags) {
+		switch {
+		case c == 'S' || c == 'A' || c == 'U':
+			terminal++
+		case (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9'):
+		default:
+			return fmt.Errorf("%w: naptr flag %q is not alphanumeric", ErrInvalidRecord, c)
+		}
+	}
+	if terminal > 1 {
+		return fmt.Errorf("%w: naptr flags %q: S, A and U are exclusive", ErrInvalidRecord, flags)
+	}
+	return nil
+}
+
+// validateNAPTRRegexp delim-char ere delim-char repl delim-char flags of RFC 3402
+func validateNAPTRRegexp(expr string) error {
+	delim := expr[0]
+	if delim == '\\' || delim == 'i' || (delim >= '0' && delim <= '9') {
+		return fmt.Errorf("%w: naptr regexp %q has invalid delimiter", ErrInvalidRecord, expr)
+	}
+	var (
+		parts []string
+		cur   []byte
+	)
+	for i := 1; i < len(expr); i++ {
+		switch {
+		case expr[i] == '\\' && i+1 < len(expr):
+			cur = append(cur, expr[i], expr[i+1])
+			i++
+		case expr[i] == delim:
+			parts = append(parts, string(cur))
+			cur = nil
+		default:
+			cur = append(cur, expr[i])
+		}
+	}
+	parts = append(parts, string(cur))
+	// nolint: gomnd
+	if len(parts) != 3 || (parts[2] != "" && parts[2] != "i") {
+		return fmt.Errorf("%w: naptr regexp %q: want %cere%crepl%c[i]", ErrInvalidRecord, expr, delim, delim, delim)
+	}
+	ere := parts[0]
+	if parts[2] == "i" {
+		ere = "(?i)" + ere
+	}
+	if _, err := regexp.Compile(ere); err != nil {
+		return fmt.Errorf("%w: naptr regexp %q: %v", ErrInvalidRecord, expr, err)
+	}
+	return nil
+}
+
+// RecordTypeURI as type of record: priority weight target, target could be quoted
+type RecordTypeURI string
+
+// ToContent convertor, nil for invalid value
+func (uri RecordTypeURI) ToContent() []any {
+	content, _ := uri.parse()
+	return content
+}
+
+// Validate value, target must be absolute URI
+func (uri RecordTypeURI) Validate() error {
+	_, err := uri.parse()
+	return err
+}
+
+func (uri RecordTypeURI) parse() ([]any, error) {
+	parts := strings.Fields(string(uri))
+	// nolint: gomnd
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("%w: uri %q: want priority weight target", ErrInvalidRecord, uri)
+	}
+	priority, err := parseRecordField(parts[0], "uri priority", math.MaxUint16, nil)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := parseRecordField(parts[1], "uri weight", math.MaxUint16, nil)
+	if err != nil {
+		return nil, err
+	}
+	target := strings.Join(parts[2:], " ")
+	if strings.HasPrefix(target, `"`) {
+		if target, err = ParseTXT(target); err != nil {
+			return nil, fmt.Errorf("%w: uri target: %v", ErrInvalidRecord, err)
+		}
+	}
+	if u, errURL := url.Parse(target); errURL != nil || u.Scheme == "" {
+		return nil, fmt.Errorf("%w: uri target %q is not absolute uri", ErrInvalidRecord, target)
+	}
+	return []any{priority, weight, target}, nil
+}
+
+// RecordTypeCERT as type of record: type key-tag algorithm certificate,
+// type and algorithm could be mnemonics like PKIX and ECDSAP256SHA256, certificate is base64
+type RecordTypeCERT string
+
+// ToContent convertor, nil for invalid value
+func (cert RecordTypeCERT) ToContent() []any {
+	content, _ := cert.parse()
+	return content
+}
+
+// Validate value
+func (cert RecordTypeCERT) Validate() error {
+	_, err := cert.parse()
+	return err
+}
+
+func (cert RecordTypeCERT) parse() ([]any, error) {
+	parts := strings.Fields(string(cert))
+	// nolint: gomnd
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("%w: cert %q: want type key-tag algorithm certificate", ErrInvalidRecord, cert)
+	}
+	certType, err := parseRecordField(parts[0], "cert type", math.MaxUint16, certTypes)
+	if err != nil {
+		return nil, err
+	}
+	keyTag, err := parseRecordField(parts[1], "cert key tag", math.MaxUint16, nil)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := ParseDNSSecAlgorithm(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: cert: %v", ErrInvalidRecord, err)
+	}
+	data := strings.Join(parts[3:], "")
+	if _, err = base64.StdEncoding.DecodeString(data); err != nil {
+		return nil, fmt.Errorf("%w: cert certificate is not base64: %v", ErrInvalidRecord, err)
+	}
+	return []any{certType, keyTag, int64(algorithm), data}, nil
+}
+
+// parseRecordField number up to max or mnemonic of names
+func parseRecordField(s, field string, max uint64, names map[string]int64) (int64, error) {
+	if v, ok := names[alnum(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || v > max {
+		return 0, fmt.Errorf("%w: %s %q must be number up to %d", ErrInvalidRecord, field, s, max)
+	}
+	return int64(v), nil
+}
+
+// parseRecordHex non-empty hex of size bytes, any size when size is 0
+func parseRecordHex(s, field string, size int) (string, error) {
+	bs, err := hex.DecodeString(s)
+	switch {
+	case err != nil || len(bs) == 0:
+		return "", fmt.Errorf("%w: %s %q is not hex", ErrInvalidRecord, field, s)
+	case size > 0 && len(bs) != size:
+		return "", fmt.Errorf("%w: %s must be %d bytes, got %d", ErrInvalidRecord, field, size, len(bs))
+	}
+	return s, nil
+}
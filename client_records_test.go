@@ -0,0 +1,217 @@
+package dnssdk
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sha256Hex = "8cdda8e14bbf2ee8d7ccbd4e55eb5d5e0c4ee2b5b6a21d5b5a4f3c1b0e9d8c7a"
+	sha1Hex   = "123456789abcdef67890123456789abcdef67890"
+)
+
+func TestRecordTypes_ToContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		rType   string
+		content string
+		want    []any
+		wantErr string
+	}{
+		{
+			name:    "tlsa",
+			rType:   "TLSA",
+			content: "3 1 1 " + sha256Hex[:32] + " " + sha256Hex[32:],
+			want:    []any{int64(3), int64(1), int64(1), sha256Hex},
+		},
+		{
+			name:    "tlsa mnemonics",
+			rType:   "tlsa",
+			content: "DANE-EE SPKI SHA2-256 " + sha256Hex,
+			want:    []any{int64(3), int64(1), int64(1), sha256Hex},
+		},
+		{
+			name:    "tlsa full certificate",
+			rType:   "TLSA",
+			content: "0 0 0 30820122",
+			want:    []any{int64(0), int64(0), int64(0), "30820122"},
+		},
+		{
+			name:    "tlsa sha512 length",
+			rType:   "TLSA",
+			content: "3 1 2 " + sha256Hex,
+			wantErr: "tlsa data must be 64 bytes, got 32",
+		},
+		{
+			name:    "tlsa usage",
+			rType:   "TLSA",
+			content: "4 1 1 " + sha256Hex,
+			wantErr: `tlsa usage "4" must be number up to 3`,
+		},
+		{
+			name:    "tlsa hex",
+			rType:   "TLSA",
+			content: "3 1 0 xyz",
+			wantErr: "is not hex",
+		},
+		{
+			name:    "sshfp",
+			rType:   "SSHFP",
+			content: "4 2 " + sha256Hex,
+			want:    []any{int64(4), int64(2), sha256Hex},
+		},
+		{
+			name:    "sshfp sha1",
+			rType:   "SSHFP",
+			content: "1 1 " + sha1Hex,
+			want:    []any{int64(1), int64(1), sha1Hex},
+		},
+		{
+			name:    "sshfp length",
+			rType:   "SSHFP",
+			content: "1 2 " + sha1Hex,
+			wantErr: "sshfp fingerprint must be 32 bytes, got 20",
+		},
+		{
+			name:    "sshfp algorithm",
+			rType:   "SSHFP",
+			content: "5 2 " + sha256Hex,
+			wantErr: "sshfp algorithm 5 is unknown",
+		},
+		{
+			name:    "naptr quoted",
+			rType:   "NAPTR",
+			content: `100 10 "u" "E2U+sip" "!^\\+1(.*)$!sip:\\1@example.com!" .`,
+			want:    []any{int64(100), int64(10), "u", "E2U+sip", `!^\+1(.*)$!sip:\1@example.com!`, "."},
+		},
+		{
+			name:    "naptr replacement",
+			rType:   "NAPTR",
+			content: `10 0 "S" "SIP+D2U" "" _sip._udp.example.com.`,
+			want:    []any{int64(10), int64(0), "S", "SIP+D2U", "", "_sip._udp.example.com."},
+		},
+		{
+			name:    "naptr unquoted empty fields",
+			rType:   "NAPTR",
+			content: `10 0    _sip._udp.example.com.`,
+			want:    []any{int64(10), int64(0), "", "", "", "_sip._udp.example.com."},
+		},
+		{
+			name:    "naptr exclusive flags",
+			rType:   "NAPTR",
+			content: `10 0 "SU" "E2U+sip" "!^.*$!sip:a@example.com!" .`,
+			wantErr: "S, A and U are exclusive",
+		},
+		{
+			name:    "naptr regexp and replacement",
+			rType:   "NAPTR",
+			content: `10 0 "U" "E2U+sip" "!^.*$!sip:a@example.com!" example.com.`,
+			wantErr: "regexp and replacement are exclusive",
+		},
+		{
+			name:    "naptr U without regexp",
+			rType:   "NAPTR",
+			content: `10 0 "U" "E2U+sip" "" example.com.`,
+			wantErr: "flag U requires regexp",
+		},
+		{
+			name:    "naptr bad regexp",
+			rType:   "NAPTR",
+			content: `10 0 "U" "E2U+sip" "!^(.*$!sip:a@example.com!" .`,
+			wantErr: "missing closing )",
+		},
+		{
+			name:    "naptr regexp syntax",
+			rType:   "NAPTR",
+			content: `10 0 "U" "E2U+sip" "!^.*$!sip:a@example.com" .`,
+			wantErr: "want !ere!repl![i]",
+		},
+		{
+			name:    "naptr flag",
+			rType:   "NAPTR",
+			content: `10 0 "S-" "SIP+D2U" "" example.com.`,
+			wantErr: "naptr flag '-' is not alphanumeric",
+		},
+		{
+			name:    "uri",
+			rType:   "URI",
+			content: `10 1 "ftp://ftp1.example.com/public"`,
+			want:    []any{int64(10), int64(1), "ftp://ftp1.example.com/public"},
+		},
+		{
+			name:    "uri relative",
+			rType:   "URI",
+			content: `10 1 "/public"`,
+			wantErr: "is not absolute uri",
+		},
+		{
+			name:    "cert",
+			rType:   "CERT",
+			content: "PKIX 12345 ECDSAP256SHA256 MIIB AA==",
+			want:    []any{int64(1), int64(12345), int64(13), "MIIBAA=="},
+		},
+		{
+			name:    "cert base64",
+			rType:   "CERT",
+			content: "1 0 8 ***",
+			wantErr: "cert certificate is not base64",
+		},
+		{
+			name:    "cert algorithm",
+			rType:   "CERT",
+			content: "1 0 NOPE AA==",
+			wantErr: `unknown dnssec algorithm "NOPE"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := ToRecordType(tt.rType, tt.content)
+			err := rt.(interface{ Validate() error }).Validate()
+			if tt.wantErr != "" {
+				assert.True(t, errors.Is(err, ErrInvalidRecord))
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, rt.ToContent())
+				return
+			}
+			require.NoError(t, err)
+			if got := ContentFromValue(tt.rType, tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContentFromValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordTypes_ContentToString(t *testing.T) {
+	tests := map[string]string{
+		"TLSA":  "3 1 1 " + sha256Hex,
+		"SSHFP": "4 2 " + sha256Hex,
+		"NAPTR": `100 10 "U" "E2U+sip" "!^\\+1(.*)$!sip:\\1@example.com!" .`,
+		"URI":   `10 1 "https://example.com/a b"`,
+		"CERT":  "PKIX 12345 8 MIIBAA==",
+	}
+	for rType, value := range tests {
+		content := ContentFromValue(rType, value)
+		require.NotNil(t, content, rType)
+
+		// numbers are float64 after API response
+		bs, err := json.Marshal(ResourceRecord{Content: content})
+		require.NoError(t, err)
+		var record ResourceRecord
+		require.NoError(t, json.Unmarshal(bs, &record))
+
+		str := record.ContentToString()
+		assert.False(t, strings.Contains(str, "e+"), str)
+		assert.Equal(t, content, ContentFromValue(rType, str), "%s %s", rType, str)
+	}
+
+	naptr := ResourceRecord{Content: []any{float64(10), float64(0), "S", "SIP+D2U", "", "_sip._udp.example.com."}}
+	assert.Equal(t, "10 0 S SIP+D2U  _sip._udp.example.com.", naptr.ContentToString())
+	assert.Equal(t, []any{int64(10), int64(0), "S", "SIP+D2U", "", "_sip._udp.example.com."},
+		ContentFromValue("NAPTR", naptr.ContentToString()))
+}
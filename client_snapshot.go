@@ -0,0 +1,520 @@
+package dnssdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SnapshotVersion of snapshot format written by Snapshot
+const SnapshotVersion = 1
+
+const (
+	metaGeodnsLink = "geodns_link"
+	metaFailover   = "failover"
+)
+
+// Snapshot of zone for backup, it is encoded to stable JSON: rrsets and records are sorted
+type Snapshot struct {
+	Version int         `json:"version"`
+	Zone    string      `json:"zone"`
+	TakenAt time.Time   `json:"taken_at"`
+	SOA     SnapshotSOA `json:"soa"`
+	Meta    RRSetMeta   `json:"meta,omitempty"`
+	DNSSEC  bool        `json:"dnssec"`
+	// DS published at parent when snapshot was taken
+	DS     *DNSSecDS       `json:"ds,omitempty"`
+	RRSets []SnapshotRRSet `json:"rrsets"`
+	// NetworkMappings referenced by geodns_link of rrsets
+	NetworkMappings []SnapshotNetworkMapping `json:"network_mappings,omitempty"`
+}
+
+// SnapshotSOA settings of zone
+type SnapshotSOA struct {
+	PrimaryServer string `json:"primary_server,omitempty"`
+	Contact       string `json:"contact,omitempty"`
+	Serial        uint64 `json:"serial,omitempty"`
+	Refresh       uint64 `json:"refresh,omitempty"`
+	Retry         uint64 `json:"retry,omitempty"`
+	Expiry        uint64 `json:"expiry,omitempty"`
+	NxTTL         uint64 `json:"nx_ttl,omitempty"`
+}
+
+// SnapshotRRSet RRSet with its name
+type SnapshotRRSet struct {
+	Name    string           `json:"name"`
+	Type    string           `json:"type"`
+	TTL     int              `json:"ttl"`
+	Records []ResourceRecord `json:"resource_records"`
+	Filters []RecordFilter   `json:"filters,omitempty"`
+	Meta    RRSetMeta        `json:"meta,omitempty"`
+}
+
+// SnapshotNetworkMapping reference to network mapping, restore finds it by name
+type SnapshotNetworkMapping struct {
+	ID   uint64 `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// RRSet dto of snapshot item
+func (s SnapshotRRSet) RRSet() RRSet {
+	return RRSet{Type: s.Type, TTL: s.TTL, Records: s.Records, Filters: s.Filters, Meta: s.Meta}
+}
+
+// WriteTo writes indented JSON of snapshot
+func (s Snapshot) WriteTo(w io.Writer) (int64, error) {
+	bs, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("encode snapshot: %w", err)
+	}
+	n, err := w.Write(append(bs, '\n'))
+	return int64(n), err
+}
+
+// ZoneFile of snapshot rrsets for ImportZone, TXT values are split into quoted character-strings.
+// Disabled records are written as comments.
+func (s Snapshot) ZoneFile() string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", s.Zone)
+	for _, set := range s.RRSets {
+		for _, r := range set.Records {
+			content := r.ContentToString()
+			if strings.EqualFold(set.Type, "TXT") || strings.EqualFold(set.Type, "SPF") {
+				content = QuoteTXT(content)
+			}
+			if !r.Enabled {
+				b.WriteString("; ")
+			}
+			fmt.Fprintf(&b, "%s.\t%d\tIN\t%s\t%s\n", set.Name, set.TTL, set.Type, content)
+		}
+	}
+	return b.String()
+}
+
+// ReadSnapshot decodes snapshot and checks its version
+func ReadSnapshot(r io.Reader) (Snapshot, error) {
+	var snap Snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return Snapshot{}, fmt.Errorf("decode snapshot: %w", err)
+	}
+	if snap.Version < 1 || snap.Version > SnapshotVersion {
+		// nolint: goerr113
+		return Snapshot{}, fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+	return snap, nil
+}
+
+// Snapshot captures zone settings, rrsets, dnssec state and network mapping references
+func (c *Client) Snapshot(ctx context.Context, zone string) (Snapshot, error) {
+	zone = strings.Trim(zone, ".")
+	info, err := c.Zone(ctx, zone)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	rrsets, err := c.ZoneRRSets(ctx, zone)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snap := Snapshot{
+		Version: SnapshotVersion,
+		Zone:    zone,
+		TakenAt: time.Now().UTC().Truncate(time.Second),
+		SOA: SnapshotSOA{
+			PrimaryServer: info.PrimaryServer,
+			Contact:       info.Contact,
+			Serial:        info.Serial,
+			Refresh:       info.Refresh,
+			Retry:         info.Retry,
+			Expiry:        info.Expiry,
+			NxTTL:         info.NxTTL,
+		},
+		Meta:   info.Meta,
+		DNSSEC: info.DNSSECEnabled,
+		RRSets: make([]SnapshotRRSet, 0, len(rrsets)),
+	}
+	if info.DNSSECEnabled {
+		ds, errDS := c.DNSSecDS(ctx, zone)
+		if errDS != nil {
+			return Snapshot{}, errDS
+		}
+		snap.DS = &ds
+	}
+
+	links := map[string]bool{}
+	for _, set := range rrsets {
+		records := append([]ResourceRecord{}, set.Records...)
+		sort.SliceStable(records, func(i, j int) bool {
+			return records[i].ContentToString() < records[j].ContentToString()
+		})
+		snap.RRSets = append(snap.RRSets, SnapshotRRSet{
+			Name:    strings.Trim(set.Name, "."),
+			Type:    set.Type,
+			TTL:     set.TTL,
+			Records: records,
+			Filters: set.Filters,
+			Meta:    set.Meta,
+		})
+		if link, ok := set.Meta[metaGeodnsLink].(string); ok && link != "" {
+			links[link] = true
+		}
+	}
+	sortSnapshotRRSets(snap.RRSets)
+
+	for link := range links {
+		ref := SnapshotNetworkMapping{Name: link}
+		mapping, errGet := c.GetNetworkMappingByName(ctx, link)
+		switch {
+		case errGet == nil:
+			ref.ID = mapping.ID
+		case !isNotFound(errGet):
+			return Snapshot{}, fmt.Errorf("network mapping %s: %w", link, errGet)
+		}
+		snap.NetworkMappings = append(snap.NetworkMappings, ref)
+	}
+	sort.Slice(snap.NetworkMappings, func(i, j int) bool {
+		return snap.NetworkMappings[i].Name < snap.NetworkMappings[j].Name
+	})
+
+	return snap, nil
+}
+
+// RestoreOptions for Restore
+type RestoreOptions struct {
+	// Target zone name, zone of snapshot when empty
+	Target string
+	// Prune deletes rrsets which are not in snapshot
+	Prune bool
+	// SkipDNSSEC keeps dnssec state of target zone
+	SkipDNSSEC bool
+	// RestoreApex restores SOA and apex NS rrsets of snapshot, they are managed by zone and skipped by default
+	RestoreApex bool
+}
+
+// RestoreIssue part of snapshot which was not restored or restored partially
+type RestoreIssue struct {
+	Item   string
+	Reason string
+}
+
+// RestoreResult of Restore, items are "name type" of rrsets
+type RestoreResult struct {
+	Zone        string
+	ZoneCreated bool
+	Created     []string
+	Updated     []string
+	Unchanged   []string
+	Deleted     []string
+	Issues      []RestoreIssue
+}
+
+// Restore recreates or reconciles zone from snapshot.
+// When target differs from zone of snapshot, names and in-zone targets of records are moved to target zone.
+// Parts rejected by API, e.g. failover checks on unsupported plan, are reported in Issues,
+// error is returned only when restore could not continue.
+func (c *Client) Restore(ctx context.Context, snap Snapshot, opts RestoreOptions) (RestoreResult, error) {
+	if snap.Version < 1 || snap.Version > SnapshotVersion {
+		// nolint: goerr113
+		return RestoreResult{}, fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+	target := strings.Trim(opts.Target, ".")
+	if target == "" {
+		target = snap.Zone
+	}
+	res := RestoreResult{Zone: target}
+
+	current, err := c.restoreZone(ctx, snap, target, &res)
+	if err != nil {
+		return res, err
+	}
+
+	mappings, err := c.restoreNetworkMappings(ctx, snap, &res)
+	if err != nil {
+		return res, err
+	}
+
+	existing := map[string]ZoneRRSet{}
+	if !res.ZoneCreated {
+		sets, errList := c.ZoneRRSets(ctx, target)
+		if errList != nil {
+			return res, errList
+		}
+		for _, set := range sets {
+			existing[strings.Trim(set.Name, ".")+" "+set.Type] = set
+		}
+	}
+
+	desired := map[string]bool{}
+	for _, set := range snap.RRSets {
+		name := renameHost(set.Name, snap.Zone, target)
+		key := name + " " + set.Type
+		desired[key] = true
+		if !opts.RestoreApex && isApexRRSet(name, set.Type, target) {
+			continue
+		}
+		rrset := set.RRSet()
+		rrset.Records = renameRecords(set.Type, rrset.Records, snap.Zone, target)
+		if link, ok := rrset.Meta[metaGeodnsLink].(string); ok && !mappings[link] {
+			rrset.Meta = copyMetaWithout(rrset.Meta, metaGeodnsLink)
+			res.Issues = append(res.Issues, RestoreIssue{Item: key,
+				Reason: fmt.Sprintf("network mapping %s not found, geodns_link is removed", link)})
+		}
+		if errSet := c.restoreRRSet(ctx, target, name, rrset, existing, &res); errSet != nil {
+			return res, errSet
+		}
+	}
+
+	if opts.Prune {
+		keys := make([]string, 0, len(existing))
+		for key := range existing {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			set := existing[key]
+			if desired[key] || isApexRRSet(set.Name, set.Type, target) {
+				continue
+			}
+			if errDel := c.DeleteRRSet(ctx, target, set.Name, set.Type); errDel != nil {
+				if !isClientError(errDel) {
+					return res, errDel
+				}
+				res.Issues = append(res.Issues, RestoreIssue{Item: key, Reason: "delete: " + errDel.Error()})
+				continue
+			}
+			res.Deleted = append(res.Deleted, key)
+		}
+	}
+
+	if !opts.SkipDNSSEC && snap.DNSSEC != current.DNSSECEnabled {
+		ds, errToggle := c.ToggleDnssec(ctx, target, snap.DNSSEC)
+		switch {
+		case errToggle == nil && snap.DNSSEC && (snap.DS == nil || snap.DS.Ds != ds.Ds):
+			res.Issues = append(res.Issues, RestoreIssue{Item: "dnssec",
+				Reason: "zone is signed by new keys, publish new DS at parent: " + ds.Ds})
+		case errToggle != nil && !isClientError(errToggle):
+			return res, errToggle
+		case errToggle != nil:
+			res.Issues = append(res.Issues, RestoreIssue{Item: "dnssec", Reason: errToggle.Error()})
+		}
+	}
+
+	return res, nil
+}
+
+// restoreZone creates zone or updates its SOA settings, returns state of zone before restore
+func (c *Client) restoreZone(ctx context.Context, snap Snapshot, target string, res *RestoreResult) (Zone, error) {
+	settings := AddZone{
+		Name:          target,
+		Contact:       snap.SOA.Contact,
+		PrimaryServer: snap.SOA.PrimaryServer,
+		Refresh:       snap.SOA.Refresh,
+		Retry:         snap.SOA.Retry,
+		Expiry:        snap.SOA.Expiry,
+		NxTTL:         snap.SOA.NxTTL,
+		Meta:          snap.Meta,
+		Enabled:       true,
+	}
+	if target != snap.Zone {
+		// primary server of other zone name could be rejected
+		settings.PrimaryServer = ""
+	}
+
+	current, err := c.Zone(ctx, target)
+	switch {
+	case isNotFound(err):
+		if _, err = c.CreateZone(ctx, settings); err != nil {
+			return Zone{}, err
+		}
+		res.ZoneCreated = true
+		return Zone{Name: target}, nil
+	case err != nil:
+		return Zone{}, err
+	}
+
+	if current.Contact != settings.Contact || current.Refresh != settings.Refresh || current.Retry != settings.Retry ||
+		current.Expiry != settings.Expiry || current.NxTTL != settings.NxTTL ||
+		(settings.PrimaryServer != "" && current.PrimaryServer != settings.PrimaryServer) {
+		if _, err = c.UpdateZone(ctx, target, settings); err != nil {
+			if !isClientError(err) {
+				return Zone{}, err
+			}
+			res.Issues = append(res.Issues, RestoreIssue{Item: "soa", Reason: err.Error()})
+		}
+	}
+
+	return current, nil
+}
+
+// restoreNetworkMappings returns names of referenced mappings existing in account
+func (c *Client) restoreNetworkMappings(ctx context.Context, snap Snapshot, res *RestoreResult) (map[string]bool, error) {
+	found := map[string]bool{}
+	for _, ref := range snap.NetworkMappings {
+		_, err := c.GetNetworkMappingByName(ctx, ref.Name)
+		switch {
+		case err == nil:
+			found[ref.Name] = true
+		case isNotFound(err):
+			res.Issues = append(res.Issues, RestoreIssue{Item: "network mapping " + ref.Name, Reason: "not found"})
+		default:
+			return nil, fmt.Errorf("network mapping %s: %w", ref.Name, err)
+		}
+	}
+	return found, nil
+}
+
+func (c *Client) restoreRRSet(ctx context.Context, zone, name string, rrset RRSet,
+	existing map[string]ZoneRRSet, res *RestoreResult) error {
+	key := name + " " + rrset.Type
+	current, exists := existing[key]
+	if exists && sameRRSet(current.RRSet, rrset) {
+		res.Unchanged = append(res.Unchanged, key)
+		return nil
+	}
+
+	apply := func(set RRSet) error {
+		if exists {
+			return c.UpdateRRSet(ctx, zone, name, set.Type, set)
+		}
+		return c.CreateRRSet(ctx, zone, name, set.Type, set)
+	}
+
+	err := apply(rrset)
+	if err != nil && isClientError(err) && rrset.Meta[metaFailover] != nil {
+		// failover checks are not available on every plan, restore records without them
+		errFailover := err
+		rrset.Meta = copyMetaWithout(rrset.Meta, metaFailover)
+		if err = apply(rrset); err == nil {
+			res.Issues = append(res.Issues, RestoreIssue{Item: key,
+				Reason: "failover is removed: " + errFailover.Error()})
+		}
+	}
+	switch {
+	case err == nil && exists:
+		res.Updated = append(res.Updated, key)
+	case err == nil:
+		res.Created = append(res.Created, key)
+	case isClientError(err):
+		res.Issues = append(res.Issues, RestoreIssue{Item: key, Reason: err.Error()})
+	default:
+		return fmt.Errorf("restore %s: %w", key, err)
+	}
+	return nil
+}
+
+// isApexRRSet for SOA and apex NS which are managed by zone, names and types in any case
+func isApexRRSet(name, recordType, zone string) bool {
+	return strings.EqualFold(recordType, "SOA") || (strings.EqualFold(recordType, nsRecordType) &&
+		strings.EqualFold(strings.Trim(name, "."), strings.Trim(zone, ".")))
+}
+
+// hostContentIndex position of host name in content of record types
+var hostContentIndex = map[string]int{
+	"CNAME": 0, "DNAME": 0, "NS": 0, "PTR": 0, "MX": 1, "SRV": 3, "SVCB": 1, "HTTPS": 1,
+}
+
+// renameRecords moves host names of records which are in zone to target zone, records are copied
+func renameRecords(recordType string, records []ResourceRecord, zone, target string) []ResourceRecord {
+	i, ok := hostContentIndex[strings.ToUpper(recordType)]
+	if !ok || zone == target {
+		return records
+	}
+	res := make([]ResourceRecord, len(records))
+	for n, record := range records {
+		res[n] = record
+		if i >= len(record.Content) {
+			continue
+		}
+		host, isStr := record.Content[i].(string)
+		if !isStr {
+			continue
+		}
+		res[n].Content = append([]any{}, record.Content...)
+		res[n].Content[i] = renameHost(host, zone, target)
+	}
+	return res
+}
+
+// renameHost moves host name from zone to target zone ignoring case, names out of zone are kept
+func renameHost(host, zone, target string) string {
+	zone, target = strings.Trim(zone, "."), strings.Trim(target, ".")
+	if strings.EqualFold(zone, target) {
+		return host
+	}
+	name := strings.TrimSuffix(host, ".")
+	dot := host[len(name):]
+	switch {
+	case strings.EqualFold(name, zone):
+		return target + dot
+	case len(name) > len(zone) && strings.EqualFold(name[len(name)-len(zone)-1:], "."+zone):
+		return name[:len(name)-len(zone)] + target + dot
+	}
+	return host
+}
+
+// sameRRSet compares rrsets as API returns them, order of records does not matter
+func sameRRSet(a, b RRSet) bool {
+	if a.TTL != b.TTL || len(a.Records) != len(b.Records) ||
+		!sameJSON(a.Filters, b.Filters) || !sameJSON(a.Meta, b.Meta) {
+		return false
+	}
+	if !sameContents(a.Records, b.Records) {
+		return false
+	}
+	records := map[string]ResourceRecord{}
+	for _, r := range a.Records {
+		records[r.ContentToString()] = r
+	}
+	for _, r := range b.Records {
+		other := records[r.ContentToString()]
+		if other.Enabled != r.Enabled || !sameJSON(other.Meta, r.Meta) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameJSON compares values by their JSON, nil and empty are the same
+func sameJSON(a, b interface{}) bool {
+	normalize := func(v interface{}) interface{} {
+		bs, _ := json.Marshal(v)
+		var res interface{}
+		_ = json.Unmarshal(bs, &res)
+		switch t := res.(type) {
+		case map[string]interface{}:
+			if len(t) == 0 {
+				return nil
+			}
+		case []interface{}:
+			if len(t) == 0 {
+				return nil
+			}
+		}
+		return res
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func copyMetaWithout(meta RRSetMeta, key string) RRSetMeta {
+	res := make(RRSetMeta, len(meta))
+	for k, v := range meta {
+		if k != key {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// isClientError is 4xx error of API, request should not be repeated as is
+func isClientError(err error) bool {
+	errAPI := new(APIError)
+	return errors.As(err, errAPI) && errAPI.StatusCode >= http.StatusBadRequest &&
+		errAPI.StatusCode < http.StatusInternalServerError
+}
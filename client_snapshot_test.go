@@ -0,0 +1,340 @@
+package dnssdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI in-memory zones, rrsets and network mappings of one account
+type fakeAPI struct {
+	mu       sync.Mutex
+	zones    map[string]*Zone
+	rrsets   map[string]map[string]ZoneRRSet
+	mappings map[string]NetworkMappingResponse
+	// noFailover rejects rrsets with failover meta like plan without healthchecks
+	noFailover bool
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *Client) {
+	t.Helper()
+
+	f := &fakeAPI{
+		zones:    map[string]*Zone{},
+		rrsets:   map[string]map[string]ZoneRRSet{},
+		mappings: map[string]NetworkMappingResponse{},
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	client := NewClient(PermanentAPIKeyAuth(testToken))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	return f, client
+}
+
+func (f *fakeAPI) addZone(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.zones[name] = &Zone{Name: name, PrimaryServer: "ns1." + name, Contact: "admin." + name, Refresh: 3600}
+	f.rrsets[name] = map[string]ZoneRRSet{}
+}
+
+func (f *fakeAPI) addRRSet(zone, name string, set RRSet) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rrsets[zone][name+" "+set.Type] = ZoneRRSet{Name: name, RRSet: set}
+}
+
+func (f *fakeAPI) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	notFound := func() {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"error":"not found"}`))
+	}
+	if len(parts) < 2 || parts[0] != "v2" {
+		notFound()
+		return
+	}
+
+	if parts[1] == "network-mappings" && len(parts) == 3 && req.Method == http.MethodGet {
+		mapping, ok := f.mappings[parts[2]]
+		if !ok {
+			notFound()
+			return
+		}
+		handleJSONResponse(map[string]NetworkMappingResponse{"network_mapping": mapping})(rw, req)
+		return
+	}
+	if parts[1] != "zones" {
+		notFound()
+		return
+	}
+
+	if len(parts) == 2 && req.Method == http.MethodPost {
+		var add AddZone
+		_ = json.NewDecoder(req.Body).Decode(&add)
+		f.zones[add.Name] = &Zone{Name: add.Name, Contact: add.Contact, PrimaryServer: add.PrimaryServer,
+			Refresh: add.Refresh, Retry: add.Retry, Expiry: add.Expiry, NxTTL: add.NxTTL, Meta: add.Meta}
+		f.rrsets[add.Name] = map[string]ZoneRRSet{}
+		handleJSONResponse(CreateResponse{ID: uint64(len(f.zones))})(rw, req)
+		return
+	}
+	if len(parts) < 3 {
+		notFound()
+		return
+	}
+	zone, ok := f.zones[parts[2]]
+	if !ok {
+		notFound()
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && req.Method == http.MethodGet:
+		handleJSONResponse(zone)(rw, req)
+	case len(parts) == 3 && req.Method == http.MethodPut:
+		var add AddZone
+		_ = json.NewDecoder(req.Body).Decode(&add)
+		zone.Contact, zone.Refresh, zone.Retry, zone.Expiry, zone.NxTTL = add.Contact, add.Refresh, add.Retry, add.Expiry, add.NxTTL
+		if add.PrimaryServer != "" {
+			zone.PrimaryServer = add.PrimaryServer
+		}
+		handleJSONResponse(CreateResponse{})(rw, req)
+	case len(parts) == 4 && parts[3] == "rrsets":
+		res := ZoneRRSets{}
+		for _, set := range f.rrsets[zone.Name] {
+			res.RRSets = append(res.RRSets, set)
+		}
+		res.TotalAmount = len(res.RRSets)
+		handleJSONResponse(res)(rw, req)
+	case len(parts) == 4 && parts[3] == "dnssec" && req.Method == http.MethodGet:
+		if !zone.DNSSECEnabled {
+			notFound()
+			return
+		}
+		handleJSONResponse(DNSSecDS{Ds: zone.Name + ". 3600 IN DS 1 13 2 AA"})(rw, req)
+	case len(parts) == 4 && parts[3] == "dnssec" && req.Method == http.MethodPatch:
+		var toggle map[string]bool
+		_ = json.NewDecoder(req.Body).Decode(&toggle)
+		zone.DNSSECEnabled = toggle["enabled"]
+		handleJSONResponse(DNSSecDS{Ds: zone.Name + ". 3600 IN DS 2 13 2 BB"})(rw, req)
+	case len(parts) == 5:
+		key := parts[3] + " " + parts[4]
+		_, exists := f.rrsets[zone.Name][key]
+		switch req.Method {
+		case http.MethodGet:
+			if !exists {
+				notFound()
+				return
+			}
+			handleJSONResponse(f.rrsets[zone.Name][key].RRSet)(rw, req)
+		case http.MethodPost, http.MethodPut:
+			if (req.Method == http.MethodPost) == exists {
+				rw.WriteHeader(http.StatusConflict)
+				return
+			}
+			var set RRSet
+			_ = json.NewDecoder(req.Body).Decode(&set)
+			if f.noFailover && set.Meta[metaFailover] != nil {
+				rw.WriteHeader(http.StatusForbidden)
+				_, _ = rw.Write([]byte(`{"error":"healthchecks are not available on your plan"}`))
+				return
+			}
+			set.Type = parts[4]
+			f.rrsets[zone.Name][key] = ZoneRRSet{Name: parts[3], RRSet: set}
+		case http.MethodDelete:
+			delete(f.rrsets[zone.Name], key)
+		}
+	default:
+		notFound()
+	}
+}
+
+func snapshotFixture(t *testing.T) (*fakeAPI, *Client) {
+	t.Helper()
+
+	f, client := newFakeAPI(t)
+	f.addZone("example.com")
+	f.zones["example.com"].DNSSECEnabled = true
+	f.mappings["office"] = NetworkMappingResponse{ID: 5, Name: "office"}
+
+	www := RRSet{Type: "A", TTL: 60, Records: []ResourceRecord{
+		{Content: []any{"192.0.2.2"}, Enabled: true},
+		{Content: []any{"192.0.2.1"}, Enabled: true, Meta: map[string]any{"cidr_labels": map[string]any{"office": 1}}},
+	}}
+	www.SetMetaGeodnsLink("office")
+	www.Filters = []RecordFilter{NewGeoDNSFilter(1, true)}
+	f.addRRSet("example.com", "www.example.com", www)
+
+	api := RRSet{Type: "CNAME", TTL: 300, Records: []ResourceRecord{{Content: []any{"www.example.com."}, Enabled: true}}}
+	api.SetMetaFailover(map[string]any{"protocol": "HTTP", "port": 80, "frequency": 10, "timeout": 1})
+	f.addRRSet("example.com", "api.example.com", api)
+
+	f.addRRSet("example.com", "example.com", RRSet{Type: "MX", TTL: 3600,
+		Records: []ResourceRecord{{Content: []any{10, "mx.example.com."}, Enabled: true}}})
+
+	return f, client
+}
+
+func TestClient_Snapshot(t *testing.T) {
+	_, client := snapshotFixture(t)
+
+	snap, err := client.Snapshot(context.Background(), "example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotVersion, snap.Version)
+	assert.Equal(t, "ns1.example.com", snap.SOA.PrimaryServer)
+	assert.True(t, snap.DNSSEC)
+	require.NotNil(t, snap.DS)
+	assert.Equal(t, []SnapshotNetworkMapping{{ID: 5, Name: "office"}}, snap.NetworkMappings)
+
+	require.Len(t, snap.RRSets, 3)
+	assert.Equal(t, "api.example.com", snap.RRSets[0].Name)
+	assert.Equal(t, "example.com", snap.RRSets[1].Name)
+	assert.Equal(t, "www.example.com", snap.RRSets[2].Name)
+	// records are sorted
+	assert.Equal(t, "192.0.2.1", snap.RRSets[2].Records[0].ContentToString())
+
+	buf := bytes.Buffer{}
+	_, err = snap.WriteTo(&buf)
+	require.NoError(t, err)
+	encoded := buf.String()
+
+	read, err := ReadSnapshot(strings.NewReader(encoded))
+	require.NoError(t, err)
+	buf.Reset()
+	_, err = read.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, encoded, buf.String(), "encoding is stable")
+
+	_, err = ReadSnapshot(strings.NewReader(`{"version":99}`))
+	assert.Error(t, err)
+}
+
+func TestClient_Restore_otherZone(t *testing.T) {
+	_, source := snapshotFixture(t)
+	snap, err := source.Snapshot(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	// other account without network mapping and healthchecks
+	target, client := newFakeAPI(t)
+	target.noFailover = true
+
+	res, err := client.Restore(context.Background(), snap, RestoreOptions{Target: "example.org"})
+	require.NoError(t, err)
+	assert.True(t, res.ZoneCreated)
+	assert.ElementsMatch(t, []string{"api.example.org CNAME", "example.org MX", "www.example.org A"}, res.Created)
+
+	reasons := map[string]string{}
+	for _, issue := range res.Issues {
+		reasons[issue.Item] = issue.Reason
+	}
+	assert.Contains(t, reasons["api.example.org CNAME"], "failover is removed")
+	assert.Contains(t, reasons["www.example.org A"], "network mapping office not found")
+	assert.Equal(t, "not found", reasons["network mapping office"])
+	assert.Contains(t, reasons["dnssec"], "publish new DS")
+
+	www := target.rrsets["example.org"]["www.example.org A"]
+	assert.Len(t, www.Records, 2)
+	assert.Nil(t, www.Meta[metaGeodnsLink])
+	assert.Equal(t, []RecordFilter{NewGeoDNSFilter(1, true)}, www.Filters)
+	assert.True(t, target.zones["example.org"].DNSSECEnabled)
+	assert.Equal(t, uint64(3600), target.zones["example.org"].Refresh)
+}
+
+func TestClient_Restore_reconcile(t *testing.T) {
+	f, client := snapshotFixture(t)
+	ctx := context.Background()
+	snap, err := client.Snapshot(ctx, "example.com")
+	require.NoError(t, err)
+
+	// drift after backup
+	f.addRRSet("example.com", "www.example.com", RRSet{Type: "A", TTL: 60,
+		Records: []ResourceRecord{{Content: []any{"198.51.100.1"}, Enabled: true}}})
+	f.addRRSet("example.com", "new.example.com", RRSet{Type: "TXT", TTL: 60,
+		Records: []ResourceRecord{{Content: []any{"x"}, Enabled: true}}})
+	f.addRRSet("example.com", "example.com", RRSet{Type: nsRecordType, TTL: 60,
+		Records: []ResourceRecord{{Content: []any{"ns1.gcorelabs.net."}, Enabled: true}}})
+
+	res, err := client.Restore(ctx, snap, RestoreOptions{Prune: true})
+	require.NoError(t, err)
+	assert.False(t, res.ZoneCreated)
+	assert.Empty(t, res.Created)
+	assert.Equal(t, []string{"www.example.com A"}, res.Updated)
+	assert.ElementsMatch(t, []string{"api.example.com CNAME", "example.com MX"}, res.Unchanged)
+	assert.Equal(t, []string{"new.example.com TXT"}, res.Deleted)
+	assert.Empty(t, res.Issues)
+
+	assert.Contains(t, f.rrsets["example.com"], "example.com NS", "apex NS is kept")
+	assert.Equal(t, "office", f.rrsets["example.com"]["www.example.com A"].Meta[metaGeodnsLink])
+}
+
+func TestClient_Restore_crossZone(t *testing.T) {
+	f, source := snapshotFixture(t)
+	f.addRRSet("example.com", "example.com", RRSet{Type: nsRecordType, TTL: 3600,
+		Records: []ResourceRecord{{Content: []any{"ns1.gcorelabs.net."}, Enabled: true}}})
+	f.addRRSet("example.com", "example.com", RRSet{Type: "SOA", TTL: 3600,
+		Records: []ResourceRecord{{Content: []any{"ns1.example.com.", "admin.example.com.", 1, 3600, 3600, 1209600, 300},
+			Enabled: true}}})
+	f.addRRSet("example.com", "sub.example.com", RRSet{Type: nsRecordType, TTL: 3600,
+		Records: []ResourceRecord{{Content: []any{"ns.sub.example.com."}, Enabled: true}}})
+	f.addRRSet("example.com", "_sip._udp.example.com", RRSet{Type: "SRV", TTL: 3600,
+		Records: []ResourceRecord{{Content: []any{10, 5, 5060, "SIP.Example.com"}, Enabled: true}}})
+	f.addRRSet("example.com", "cdn.example.com", RRSet{Type: "CNAME", TTL: 3600,
+		Records: []ResourceRecord{{Content: []any{"cdn.notexample.com."}, Enabled: true}}})
+	snap, err := source.Snapshot(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	target, client := newFakeAPI(t)
+	res, err := client.Restore(context.Background(), snap, RestoreOptions{Target: "example.org", SkipDNSSEC: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"api.example.org CNAME", "example.org MX", "www.example.org A",
+		"sub.example.org NS", "_sip._udp.example.org SRV", "cdn.example.org CNAME"}, res.Created)
+
+	sets := target.rrsets["example.org"]
+	assert.NotContains(t, sets, "example.org NS", "apex NS is skipped")
+	assert.NotContains(t, sets, "example.org SOA", "SOA is skipped")
+	content := func(key string) []any {
+		return sets[key].Records[0].Content
+	}
+	assert.Equal(t, []any{"www.example.org."}, content("api.example.org CNAME"))
+	assert.Equal(t, []any{float64(10), "mx.example.org."}, content("example.org MX"))
+	assert.Equal(t, []any{"ns.sub.example.org."}, content("sub.example.org NS"))
+	assert.Equal(t, []any{float64(10), float64(5), float64(5060), "SIP.example.org"}, content("_sip._udp.example.org SRV"))
+	assert.Equal(t, []any{"cdn.notexample.com."}, content("cdn.example.org CNAME"), "out of zone target is kept")
+	for _, set := range snap.RRSets {
+		if set.Name == "api.example.com" {
+			assert.Equal(t, []any{"www.example.com."}, set.Records[0].Content, "snapshot is not changed")
+		}
+	}
+
+	res, err = client.Restore(context.Background(), snap,
+		RestoreOptions{Target: "example.net", SkipDNSSEC: true, RestoreApex: true})
+	require.NoError(t, err)
+	assert.Contains(t, res.Created, "example.net NS")
+	assert.Contains(t, res.Created, "example.net SOA")
+	assert.Equal(t, []any{"ns1.gcorelabs.net."}, target.rrsets["example.net"]["example.net NS"].Records[0].Content)
+}
+
+func TestIsApexRRSet(t *testing.T) {
+	assert.True(t, isApexRRSet("example.com", "SOA", "example.com"))
+	assert.True(t, isApexRRSet("example.com", "soa", "example.com"))
+	assert.True(t, isApexRRSet("Example.COM.", "ns", "example.com"))
+	assert.True(t, isApexRRSet("example.com", "NS", "example.com."))
+	assert.False(t, isApexRRSet("sub.example.com", "NS", "example.com"))
+	assert.False(t, isApexRRSet("example.com", "A", "example.com"))
+}
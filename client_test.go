@@ -696,3 +696,209 @@ func TestClient_DeleteNetworkMapping(t *testing.T) {
 	err := client.DeleteNetworkMapping(context.Background(), 1)
 	require.NoError(t, err)
 }
+
+func TestClient_CreateSecondaryZone(t *testing.T) {
+	mux, client := setupTest(t)
+
+	zone := AddSecondaryZone{
+		Name: "example.com",
+		PrimaryServers: []PrimaryServer{
+			{Address: "192.0.2.1", TSIGKey: "transfer-key"},
+			{Address: "2001:db8::1", Port: 5353},
+		},
+		Notify: &NotifySettings{Enabled: true},
+	}
+
+	mux.Handle("/v2/secondary-zones", validationHandler{
+		method: http.MethodPost,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			body := AddSecondaryZone{}
+			err := json.NewDecoder(req.Body).Decode(&body)
+			if err != nil {
+				http.Error(rw, "failed to decode body", http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, zone, body)
+			handleJSONResponse(CreateResponse{ID: 1})(rw, req)
+		}),
+	})
+
+	id, err := client.CreateSecondaryZone(context.Background(), zone)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), id)
+}
+
+func TestClient_CreateSecondaryZone_invalid(t *testing.T) {
+	_, client := setupTest(t)
+
+	_, err := client.CreateSecondaryZone(context.Background(), AddSecondaryZone{Name: "example.com"})
+	require.EqualError(t, err, "validate: secondary zone example.com: primary servers are empty")
+}
+
+func TestClient_UpdateSecondaryZone(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/secondary-zones/example.com", validationHandler{
+		method: http.MethodPut,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			body := map[string]any{}
+			err := json.NewDecoder(req.Body).Decode(&body)
+			if err != nil {
+				http.Error(rw, "failed to decode body", http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, "example.com", body["name"])
+			assert.Equal(t, false, body["enabled"])
+		}),
+	})
+
+	enabled := false
+	err := client.UpdateSecondaryZone(context.Background(), "example.com.", AddSecondaryZone{
+		Enabled:        &enabled,
+		PrimaryServers: []PrimaryServer{{Address: "192.0.2.1"}},
+	})
+	require.NoError(t, err)
+}
+
+func TestClient_SecondaryZone(t *testing.T) {
+	mux, client := setupTest(t)
+
+	expected := SecondaryZone{
+		ID:             1,
+		Name:           "example.com",
+		PrimaryServers: []PrimaryServer{{Address: "192.0.2.1"}},
+		Transfer:       TransferStatus{State: TransferStateOK, Serial: 2024010101},
+	}
+
+	mux.Handle("/v2/secondary-zones/example.com", validationHandler{
+		method: http.MethodGet,
+		next:   handleJSONResponse(expected),
+	})
+
+	zone, err := client.SecondaryZone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, expected, zone)
+}
+
+func TestClient_SecondaryZone_unknownTransferState(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/secondary-zones/example.com", validationHandler{
+		method: http.MethodGet,
+		next: handleJSONResponse(SecondaryZone{
+			Name:           "example.com",
+			PrimaryServers: []PrimaryServer{{Address: "192.0.2.1"}},
+			Transfer:       TransferStatus{State: "queued"},
+		}),
+	})
+
+	zone, err := client.SecondaryZone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", zone.Transfer.State)
+	assert.False(t, zone.Transfer.Known())
+}
+
+func TestClient_SecondaryZoneTransfer(t *testing.T) {
+	mux, client := setupTest(t)
+
+	expected := TransferStatus{State: TransferStateFailed, Error: "REFUSED"}
+
+	mux.Handle("/v2/secondary-zones/example.com/transfer", validationHandler{
+		method: http.MethodGet,
+		next:   handleJSONResponse(expected),
+	})
+
+	status, err := client.SecondaryZoneTransfer(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, expected, status)
+}
+
+func TestClient_RetransferSecondaryZone(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/secondary-zones/example.com/transfer", validationHandler{
+		method: http.MethodPost,
+	})
+
+	err := client.RetransferSecondaryZone(context.Background(), "example.com")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteSecondaryZone(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/secondary-zones/example.com", validationHandler{
+		method: http.MethodDelete,
+	})
+
+	err := client.DeleteSecondaryZone(context.Background(), "example.com")
+	require.NoError(t, err)
+}
+
+func TestClient_TSIGKeys(t *testing.T) {
+	mux, client := setupTest(t)
+
+	expected := []TSIGKey{
+		{ID: 1, Name: "transfer-key", Algorithm: TSIGAlgorithmHMACSHA256},
+		{ID: 2, Name: "legacy-key", Algorithm: "gss-tsig"},
+	}
+
+	mux.Handle("/v2/tsig-keys", validationHandler{
+		method: http.MethodGet,
+		next:   handleJSONResponse(ListTSIGKeys{TSIGKeys: expected, TotalAmount: 2}),
+	})
+
+	keys, err := client.TSIGKeys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, keys)
+	assert.True(t, keys[0].Known())
+	assert.False(t, keys[1].Known())
+}
+
+func TestClient_CreateTSIGKey(t *testing.T) {
+	mux, client := setupTest(t)
+
+	key := TSIGKey{Name: "transfer-key", Algorithm: "HMAC-SHA256", Secret: "c2VjcmV0"}
+
+	mux.Handle("/v2/tsig-keys", validationHandler{
+		method: http.MethodPost,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			body := TSIGKey{}
+			err := json.NewDecoder(req.Body).Decode(&body)
+			if err != nil {
+				http.Error(rw, "failed to decode body", http.StatusBadRequest)
+				return
+			}
+			// algorithm is sent in canonical form
+			assert.Equal(t, TSIGKey{Name: "transfer-key", Algorithm: TSIGAlgorithmHMACSHA256, Secret: "c2VjcmV0"}, body)
+			handleJSONResponse(CreateResponse{ID: 7})(rw, req)
+		}),
+	})
+
+	id, err := client.CreateTSIGKey(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), id)
+}
+
+func TestClient_UpdateTSIGKey(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/tsig-keys/transfer-key", validationHandler{
+		method: http.MethodPut,
+	})
+
+	err := client.UpdateTSIGKey(context.Background(), "transfer-key",
+		TSIGKey{Algorithm: TSIGAlgorithmHMACSHA512, Secret: "bmV3LXNlY3JldA=="})
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteTSIGKey(t *testing.T) {
+	mux, client := setupTest(t)
+
+	mux.Handle("/v2/tsig-keys/transfer-key", validationHandler{
+		method: http.MethodDelete,
+	})
+
+	err := client.DeleteTSIGKey(context.Background(), "transfer-key")
+	require.NoError(t, err)
+}
@@ -0,0 +1,232 @@
+package dnssdk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TXTStringLimit max length in bytes of one character-string of TXT record
+const TXTStringLimit = 255
+
+// TXTKind of TXT record value
+type TXTKind int
+
+// kinds of TXT records detected by DetectTXT
+const (
+	TXTPlain TXTKind = iota
+	TXTSPF
+	TXTDKIM
+	TXTDMARC
+	TXTVerification
+)
+
+// String implementation
+func (k TXTKind) String() string {
+	switch k {
+	case TXTSPF:
+		return "spf"
+	case TXTDKIM:
+		return "dkim"
+	case TXTDMARC:
+		return "dmarc"
+	case TXTVerification:
+		return "verification"
+	}
+	return "plain"
+}
+
+// RecordTypeTXT as type of record, value could be in zone file presentation: "part one" "part two".
+// ToRecordType keeps TXT values as is, use RecordTypeTXT or SetTXTContent to parse presentation.
+type RecordTypeTXT string
+
+// ToContent convertor, character-strings of quoted value are joined
+func (txt RecordTypeTXT) ToContent() []any {
+	value := string(txt)
+	if strings.HasPrefix(strings.TrimSpace(value), `"`) {
+		if parsed, err := ParseTXT(value); err == nil {
+			value = parsed
+		}
+	}
+	return []any{value}
+}
+
+// SetTXTContent to ResourceRecord, value could be in zone file presentation: "part one" "part two"
+func (r *ResourceRecord) SetTXTContent(value string) *ResourceRecord {
+	r.Content = RecordTypeTXT(value).ToContent()
+	return r
+}
+
+// SplitTXT splits value into character-strings of at most 255 bytes, utf-8 characters are not split
+func SplitTXT(value string) []string {
+	if value == "" {
+		return []string{""}
+	}
+	var res []string
+	for len(value) > TXTStringLimit {
+		cut := TXTStringLimit
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		res = append(res, value[:cut])
+		value = value[cut:]
+	}
+	return append(res, value)
+}
+
+// JoinTXT character-strings into value
+func JoinTXT(parts []string) string {
+	return strings.Join(parts, "")
+}
+
+// QuoteTXT zone file presentation of value: quoted character-strings of at most 255 bytes separated by space
+func QuoteTXT(value string) string {
+	parts := SplitTXT(value)
+	for i, p := range parts {
+		parts[i] = quoteTXTString(p)
+	}
+	return strings.Join(parts, " ")
+}
+
+func quoteTXTString(s string) string {
+	b := strings.Builder{}
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c == 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// ParseTXT value of zone file presentation: quoted or bare character-strings, \" \\ and \DDD escapes.
+// Character-strings are joined.
+func ParseTXT(presentation string) (string, error) {
+	parts, err := ParseTXTStrings(presentation)
+	if err != nil {
+		return "", err
+	}
+	return JoinTXT(parts), nil
+}
+
+// ParseTXTStrings character-strings of zone file presentation
+func ParseTXTStrings(presentation string) ([]string, error) {
+	var (
+		res     []string
+		cur     []byte
+		quoted  bool
+		inToken bool
+	)
+	s := presentation
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(s) {
+				// nolint: goerr113
+				return nil, fmt.Errorf("txt: dangling escape at %d", i)
+			}
+			// nolint: gomnd
+			if i+3 < len(s) && isDigit(s[i+1]) && isDigit(s[i+2]) && isDigit(s[i+3]) {
+				code, _ := strconv.Atoi(s[i+1 : i+4])
+				if code > 0xff {
+					// nolint: goerr113
+					return nil, fmt.Errorf("txt: escape \\%s out of range", s[i+1:i+4])
+				}
+				cur = append(cur, byte(code))
+				i += 3
+			} else {
+				cur = append(cur, s[i+1])
+				i++
+			}
+			inToken = true
+		case c == '"':
+			if quoted {
+				res = append(res, string(cur))
+				cur, quoted, inToken = nil, false, false
+				continue
+			}
+			if inToken {
+				// nolint: goerr113
+				return nil, fmt.Errorf("txt: unexpected quote at %d", i)
+			}
+			quoted, inToken = true, true
+		case (c == ' ' || c == '\t') && !quoted:
+			if inToken {
+				res = append(res, string(cur))
+				cur, inToken = nil, false
+			}
+		default:
+			cur = append(cur, c)
+			inToken = true
+		}
+	}
+	if quoted {
+		// nolint: goerr113
+		return nil, fmt.Errorf("txt: unterminated quote")
+	}
+	if inToken {
+		res = append(res, string(cur))
+	}
+	for _, p := range res {
+		if len(p) > TXTStringLimit {
+			// nolint: goerr113
+			return nil, fmt.Errorf("txt: character-string of %d bytes exceeds %d", len(p), TXTStringLimit)
+		}
+	}
+	return res, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// verification prefixes of well known services
+var txtVerificationPrefixes = []string{
+	"google-site-verification=",
+	"ms=",
+	"facebook-domain-verification=",
+	"apple-domain-verification=",
+	"atlassian-domain-verification=",
+	"docusign=",
+	"globalsign-domain-verification=",
+	"adobe-idp-site-verification=",
+	"stripe-verification=",
+	"zoom-domain-verification=",
+}
+
+// DetectTXT kind of TXT record by owner name and value
+func DetectTXT(name, value string) TXTKind {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	lower := strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case lower == spfPrefix || strings.HasPrefix(lower, spfPrefix+" "):
+		return TXTSPF
+	case strings.HasPrefix(lower, "v=dmarc1"):
+		return TXTDMARC
+	case strings.HasPrefix(lower, "v=dkim1") ||
+		(strings.Contains(name, "._domainkey.") || strings.HasSuffix(name, "._domainkey")) &&
+			strings.Contains(lower, "p="):
+		return TXTDKIM
+	case strings.HasPrefix(name, "_acme-challenge.") || name == "_acme-challenge":
+		return TXTVerification
+	}
+	for _, prefix := range txtVerificationPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return TXTVerification
+		}
+	}
+	if i := strings.IndexByte(lower, '='); i > 0 && strings.HasSuffix(lower[:i], "-verification") {
+		return TXTVerification
+	}
+	return TXTPlain
+}
@@ -0,0 +1,126 @@
+package dnssdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTXT(t *testing.T) {
+	long := strings.Repeat("a", 600)
+	parts := SplitTXT(long)
+	require.Len(t, parts, 3)
+	assert.Len(t, parts[0], TXTStringLimit)
+	assert.Len(t, parts[2], 600-2*TXTStringLimit)
+	assert.Equal(t, long, JoinTXT(parts))
+
+	// multibyte character is not split
+	unicode := strings.Repeat("a", 254) + "ж"
+	parts = SplitTXT(unicode)
+	assert.Equal(t, []string{strings.Repeat("a", 254), "ж"}, parts)
+
+	assert.Equal(t, []string{""}, SplitTXT(""))
+}
+
+func TestQuoteTXT(t *testing.T) {
+	assert.Equal(t, `"say \"hi\" \\ bye\009"`, QuoteTXT("say \"hi\" \\ bye\t"))
+	assert.Equal(t, `"`+strings.Repeat("k", 255)+`" "kk"`, QuoteTXT(strings.Repeat("k", 257)))
+}
+
+func TestParseTXT(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		want  []string
+		error bool
+	}{
+		{name: "quoted", in: `"v=DKIM1; k=rsa; " "p=MIIB"`, want: []string{"v=DKIM1; k=rsa; ", "p=MIIB"}},
+		{name: "bare", in: `hello world`, want: []string{"hello", "world"}},
+		{name: "escapes", in: `"a\"b\\c\065\;"`, want: []string{`a"b\cA;`}},
+		{name: "empty string", in: `""`, want: []string{""}},
+		{name: "unterminated", in: `"abc`, error: true},
+		{name: "dangling escape", in: `"abc\`, error: true},
+		{name: "escape out of range", in: `"\300"`, error: true},
+		{name: "too long", in: `"` + strings.Repeat("x", 256) + `"`, error: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTXTStrings(tt.in)
+			if tt.error {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecordTypeTXT(t *testing.T) {
+	assert.Equal(t, []any{"v=DKIM1; p=MIIB"}, RecordTypeTXT(`"v=DKIM1; " "p=MIIB"`).ToContent())
+	assert.Equal(t, []any{"v=DKIM1; p=MIIB"}, (&ResourceRecord{}).SetTXTContent(`"v=DKIM1; " "p=MIIB"`).Content)
+	// not quoted value is kept as is
+	assert.Equal(t, []any{`plain "text"`}, RecordTypeTXT(`plain "text"`).ToContent())
+
+	// SetContent and ContentFromValue send TXT value as is
+	assert.Equal(t, []any{`"v=DKIM1; " "p=MIIB"`}, (&ResourceRecord{}).SetContent("TXT", `"v=DKIM1; " "p=MIIB"`).Content)
+	assert.Equal(t, []any{`"quoted"`}, ContentFromValue("spf", `"quoted"`))
+}
+
+func TestDetectTXT(t *testing.T) {
+	tests := []struct {
+		name, value string
+		want        TXTKind
+	}{
+		{"example.com", "v=spf1 include:_spf.google.com ~all", TXTSPF},
+		{"example.com", "v=spf10", TXTPlain},
+		{"_dmarc.example.com", "v=DMARC1; p=reject", TXTDMARC},
+		{"sel._domainkey.example.com.", "v=DKIM1; k=rsa; p=MIIB", TXTDKIM},
+		{"sel._domainkey.example.com", "k=rsa; p=MIIB", TXTDKIM},
+		{"example.com", "google-site-verification=abc", TXTVerification},
+		{"example.com", "MS=ms123", TXTVerification},
+		{"example.com", "someservice-verification=xyz", TXTVerification},
+		{"example.com", "zoom-domain-verification=abc", TXTVerification},
+		{"example.com", "zoom-domain-verification-note", TXTPlain},
+		{"_acme-challenge.example.com", "token", TXTVerification},
+		{"example.com", "hello", TXTPlain},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, DetectTXT(tt.name, tt.value), tt.value)
+	}
+	assert.Equal(t, "dkim", TXTDKIM.String())
+}
+
+func TestTXT_zoneFileRoundTrip(t *testing.T) {
+	values := []string{
+		"v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12),
+		`quote " and backslash \ inside`,
+		"юникод " + strings.Repeat("я", 200),
+	}
+	snap := Snapshot{Zone: "example.com"}
+	for _, v := range values {
+		snap.RRSets = append(snap.RRSets, SnapshotRRSet{Name: "example.com", Type: "TXT", TTL: 300,
+			Records: []ResourceRecord{{Content: []any{v}, Enabled: true}}})
+	}
+
+	zoneFile := snap.ZoneFile()
+	assert.Contains(t, zoneFile, `\"`)
+
+	parsed, err := ParseZoneFile("example.com", strings.NewReader(zoneFile))
+	require.NoError(t, err)
+	require.Len(t, parsed.RRSets, 1)
+	var got []string
+	for _, r := range parsed.RRSets[0].RRSet.Records {
+		got = append(got, r.ContentToString())
+	}
+	assert.ElementsMatch(t, values, got)
+
+	// import of the same zone file sends joined values
+	for _, line := range strings.Split(strings.TrimSpace(zoneFile), "\n")[1:] {
+		content := strings.SplitN(line, "\t", 5)[4]
+		assert.Contains(t, values, RecordTypeTXT(content).ToContent()[0])
+	}
+}
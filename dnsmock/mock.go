@@ -0,0 +1,1306 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: client_api.go
+//
+// Generated by this command:
+//
+//	mockgen -source=client_api.go -destination=dnsmock/mock.go -package=dnsmock -imports=dnssdk=github.com/G-Core/gcore-dns-sdk-go
+//
+
+// Package dnsmock is a generated GoMock package.
+package dnsmock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockZonesAPI is a mock of ZonesAPI interface.
+type MockZonesAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockZonesAPIMockRecorder
+}
+
+// MockZonesAPIMockRecorder is the mock recorder for MockZonesAPI.
+type MockZonesAPIMockRecorder struct {
+	mock *MockZonesAPI
+}
+
+// NewMockZonesAPI creates a new mock instance.
+func NewMockZonesAPI(ctrl *gomock.Controller) *MockZonesAPI {
+	mock := &MockZonesAPI{ctrl: ctrl}
+	mock.recorder = &MockZonesAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZonesAPI) EXPECT() *MockZonesAPIMockRecorder {
+	return m.recorder
+}
+
+// AllZones mocks base method.
+func (m *MockZonesAPI) AllZones(ctx context.Context, nameFilters []string) ([]dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllZones", ctx, nameFilters)
+	ret0, _ := ret[0].([]dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllZones indicates an expected call of AllZones.
+func (mr *MockZonesAPIMockRecorder) AllZones(ctx, nameFilters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllZones", reflect.TypeOf((*MockZonesAPI)(nil).AllZones), ctx, nameFilters)
+}
+
+// AllZonesWithRecords mocks base method.
+func (m *MockZonesAPI) AllZonesWithRecords(ctx context.Context, nameFilters []string) ([]dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllZonesWithRecords", ctx, nameFilters)
+	ret0, _ := ret[0].([]dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllZonesWithRecords indicates an expected call of AllZonesWithRecords.
+func (mr *MockZonesAPIMockRecorder) AllZonesWithRecords(ctx, nameFilters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllZonesWithRecords", reflect.TypeOf((*MockZonesAPI)(nil).AllZonesWithRecords), ctx, nameFilters)
+}
+
+// CreateZone mocks base method.
+func (m *MockZonesAPI) CreateZone(ctx context.Context, addZone dnssdk.AddZone) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateZone", ctx, addZone)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateZone indicates an expected call of CreateZone.
+func (mr *MockZonesAPIMockRecorder) CreateZone(ctx, addZone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZone", reflect.TypeOf((*MockZonesAPI)(nil).CreateZone), ctx, addZone)
+}
+
+// DeleteZone mocks base method.
+func (m *MockZonesAPI) DeleteZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteZone indicates an expected call of DeleteZone.
+func (mr *MockZonesAPIMockRecorder) DeleteZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockZonesAPI)(nil).DeleteZone), ctx, name)
+}
+
+// DisableZone mocks base method.
+func (m *MockZonesAPI) DisableZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableZone indicates an expected call of DisableZone.
+func (mr *MockZonesAPIMockRecorder) DisableZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableZone", reflect.TypeOf((*MockZonesAPI)(nil).DisableZone), ctx, name)
+}
+
+// EnableZone mocks base method.
+func (m *MockZonesAPI) EnableZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableZone indicates an expected call of EnableZone.
+func (mr *MockZonesAPIMockRecorder) EnableZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableZone", reflect.TypeOf((*MockZonesAPI)(nil).EnableZone), ctx, name)
+}
+
+// ImportZone mocks base method.
+func (m *MockZonesAPI) ImportZone(ctx context.Context, name, content string) (dnssdk.ImportZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportZone", ctx, name, content)
+	ret0, _ := ret[0].(dnssdk.ImportZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportZone indicates an expected call of ImportZone.
+func (mr *MockZonesAPIMockRecorder) ImportZone(ctx, name, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportZone", reflect.TypeOf((*MockZonesAPI)(nil).ImportZone), ctx, name, content)
+}
+
+// UpdateZone mocks base method.
+func (m *MockZonesAPI) UpdateZone(ctx context.Context, name string, updateZone dnssdk.AddZone) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateZone", ctx, name, updateZone)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateZone indicates an expected call of UpdateZone.
+func (mr *MockZonesAPIMockRecorder) UpdateZone(ctx, name, updateZone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateZone", reflect.TypeOf((*MockZonesAPI)(nil).UpdateZone), ctx, name, updateZone)
+}
+
+// Zone mocks base method.
+func (m *MockZonesAPI) Zone(ctx context.Context, name string) (dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Zone", ctx, name)
+	ret0, _ := ret[0].(dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Zone indicates an expected call of Zone.
+func (mr *MockZonesAPIMockRecorder) Zone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Zone", reflect.TypeOf((*MockZonesAPI)(nil).Zone), ctx, name)
+}
+
+// ZoneNameservers mocks base method.
+func (m *MockZonesAPI) ZoneNameservers(ctx context.Context, name string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZoneNameservers", ctx, name)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZoneNameservers indicates an expected call of ZoneNameservers.
+func (mr *MockZonesAPIMockRecorder) ZoneNameservers(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZoneNameservers", reflect.TypeOf((*MockZonesAPI)(nil).ZoneNameservers), ctx, name)
+}
+
+// Zones mocks base method.
+func (m *MockZonesAPI) Zones(ctx context.Context, filters ...func(*dnssdk.ZonesFilter)) ([]dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Zones", varargs...)
+	ret0, _ := ret[0].([]dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Zones indicates an expected call of Zones.
+func (mr *MockZonesAPIMockRecorder) Zones(ctx any, filters ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, filters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Zones", reflect.TypeOf((*MockZonesAPI)(nil).Zones), varargs...)
+}
+
+// ZonesWithParam mocks base method.
+func (m *MockZonesAPI) ZonesWithParam(ctx context.Context, param dnssdk.ZonesParam) (dnssdk.ListZones, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZonesWithParam", ctx, param)
+	ret0, _ := ret[0].(dnssdk.ListZones)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZonesWithParam indicates an expected call of ZonesWithParam.
+func (mr *MockZonesAPIMockRecorder) ZonesWithParam(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZonesWithParam", reflect.TypeOf((*MockZonesAPI)(nil).ZonesWithParam), ctx, param)
+}
+
+// ZonesWithRecords mocks base method.
+func (m *MockZonesAPI) ZonesWithRecords(ctx context.Context, filters ...func(*dnssdk.ZonesFilter)) ([]dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ZonesWithRecords", varargs...)
+	ret0, _ := ret[0].([]dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZonesWithRecords indicates an expected call of ZonesWithRecords.
+func (mr *MockZonesAPIMockRecorder) ZonesWithRecords(ctx any, filters ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, filters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZonesWithRecords", reflect.TypeOf((*MockZonesAPI)(nil).ZonesWithRecords), varargs...)
+}
+
+// MockRRSetsAPI is a mock of RRSetsAPI interface.
+type MockRRSetsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRRSetsAPIMockRecorder
+}
+
+// MockRRSetsAPIMockRecorder is the mock recorder for MockRRSetsAPI.
+type MockRRSetsAPIMockRecorder struct {
+	mock *MockRRSetsAPI
+}
+
+// NewMockRRSetsAPI creates a new mock instance.
+func NewMockRRSetsAPI(ctrl *gomock.Controller) *MockRRSetsAPI {
+	mock := &MockRRSetsAPI{ctrl: ctrl}
+	mock.recorder = &MockRRSetsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRRSetsAPI) EXPECT() *MockRRSetsAPIMockRecorder {
+	return m.recorder
+}
+
+// AddZoneRRSet mocks base method.
+func (m *MockRRSetsAPI) AddZoneRRSet(ctx context.Context, zone, recordName, recordType string, values []dnssdk.ResourceRecord, ttl int, opts ...dnssdk.AddZoneOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, zone, recordName, recordType, values, ttl}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddZoneRRSet", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddZoneRRSet indicates an expected call of AddZoneRRSet.
+func (mr *MockRRSetsAPIMockRecorder) AddZoneRRSet(ctx, zone, recordName, recordType, values, ttl any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, zone, recordName, recordType, values, ttl}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddZoneRRSet", reflect.TypeOf((*MockRRSetsAPI)(nil).AddZoneRRSet), varargs...)
+}
+
+// CreateRRSet mocks base method.
+func (m *MockRRSetsAPI) CreateRRSet(ctx context.Context, zone, name, recordType string, record dnssdk.RRSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRRSet", ctx, zone, name, recordType, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRRSet indicates an expected call of CreateRRSet.
+func (mr *MockRRSetsAPIMockRecorder) CreateRRSet(ctx, zone, name, recordType, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRRSet", reflect.TypeOf((*MockRRSetsAPI)(nil).CreateRRSet), ctx, zone, name, recordType, record)
+}
+
+// DeleteRRSet mocks base method.
+func (m *MockRRSetsAPI) DeleteRRSet(ctx context.Context, zone, name, recordType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRRSet", ctx, zone, name, recordType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRRSet indicates an expected call of DeleteRRSet.
+func (mr *MockRRSetsAPIMockRecorder) DeleteRRSet(ctx, zone, name, recordType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRRSet", reflect.TypeOf((*MockRRSetsAPI)(nil).DeleteRRSet), ctx, zone, name, recordType)
+}
+
+// DeleteRRSetRecord mocks base method.
+func (m *MockRRSetsAPI) DeleteRRSetRecord(ctx context.Context, zone, name, recordType string, contents ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, zone, name, recordType}
+	for _, a := range contents {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRRSetRecord", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRRSetRecord indicates an expected call of DeleteRRSetRecord.
+func (mr *MockRRSetsAPIMockRecorder) DeleteRRSetRecord(ctx, zone, name, recordType any, contents ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, zone, name, recordType}, contents...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRRSetRecord", reflect.TypeOf((*MockRRSetsAPI)(nil).DeleteRRSetRecord), varargs...)
+}
+
+// RRSet mocks base method.
+func (m *MockRRSetsAPI) RRSet(ctx context.Context, zone, name, recordType string, limit, offset int) (dnssdk.RRSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RRSet", ctx, zone, name, recordType, limit, offset)
+	ret0, _ := ret[0].(dnssdk.RRSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RRSet indicates an expected call of RRSet.
+func (mr *MockRRSetsAPIMockRecorder) RRSet(ctx, zone, name, recordType, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RRSet", reflect.TypeOf((*MockRRSetsAPI)(nil).RRSet), ctx, zone, name, recordType, limit, offset)
+}
+
+// UpdateRRSet mocks base method.
+func (m *MockRRSetsAPI) UpdateRRSet(ctx context.Context, zone, name, recordType string, record dnssdk.RRSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRRSet", ctx, zone, name, recordType, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRRSet indicates an expected call of UpdateRRSet.
+func (mr *MockRRSetsAPIMockRecorder) UpdateRRSet(ctx, zone, name, recordType, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRRSet", reflect.TypeOf((*MockRRSetsAPI)(nil).UpdateRRSet), ctx, zone, name, recordType, record)
+}
+
+// ZoneRRSets mocks base method.
+func (m *MockRRSetsAPI) ZoneRRSets(ctx context.Context, zone string) ([]dnssdk.ZoneRRSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZoneRRSets", ctx, zone)
+	ret0, _ := ret[0].([]dnssdk.ZoneRRSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZoneRRSets indicates an expected call of ZoneRRSets.
+func (mr *MockRRSetsAPIMockRecorder) ZoneRRSets(ctx, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZoneRRSets", reflect.TypeOf((*MockRRSetsAPI)(nil).ZoneRRSets), ctx, zone)
+}
+
+// MockDNSSECAPI is a mock of DNSSECAPI interface.
+type MockDNSSECAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockDNSSECAPIMockRecorder
+}
+
+// MockDNSSECAPIMockRecorder is the mock recorder for MockDNSSECAPI.
+type MockDNSSECAPIMockRecorder struct {
+	mock *MockDNSSECAPI
+}
+
+// NewMockDNSSECAPI creates a new mock instance.
+func NewMockDNSSECAPI(ctrl *gomock.Controller) *MockDNSSECAPI {
+	mock := &MockDNSSECAPI{ctrl: ctrl}
+	mock.recorder = &MockDNSSECAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDNSSECAPI) EXPECT() *MockDNSSECAPIMockRecorder {
+	return m.recorder
+}
+
+// DNSSecDS mocks base method.
+func (m *MockDNSSECAPI) DNSSecDS(ctx context.Context, zone string) (dnssdk.DNSSecDS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DNSSecDS", ctx, zone)
+	ret0, _ := ret[0].(dnssdk.DNSSecDS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DNSSecDS indicates an expected call of DNSSecDS.
+func (mr *MockDNSSECAPIMockRecorder) DNSSecDS(ctx, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DNSSecDS", reflect.TypeOf((*MockDNSSECAPI)(nil).DNSSecDS), ctx, zone)
+}
+
+// ToggleDnssec mocks base method.
+func (m *MockDNSSECAPI) ToggleDnssec(ctx context.Context, zone string, enable bool) (dnssdk.DNSSecDS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleDnssec", ctx, zone, enable)
+	ret0, _ := ret[0].(dnssdk.DNSSecDS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleDnssec indicates an expected call of ToggleDnssec.
+func (mr *MockDNSSECAPIMockRecorder) ToggleDnssec(ctx, zone, enable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleDnssec", reflect.TypeOf((*MockDNSSECAPI)(nil).ToggleDnssec), ctx, zone, enable)
+}
+
+// MockNetworkMappingsAPI is a mock of NetworkMappingsAPI interface.
+type MockNetworkMappingsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkMappingsAPIMockRecorder
+}
+
+// MockNetworkMappingsAPIMockRecorder is the mock recorder for MockNetworkMappingsAPI.
+type MockNetworkMappingsAPIMockRecorder struct {
+	mock *MockNetworkMappingsAPI
+}
+
+// NewMockNetworkMappingsAPI creates a new mock instance.
+func NewMockNetworkMappingsAPI(ctrl *gomock.Controller) *MockNetworkMappingsAPI {
+	mock := &MockNetworkMappingsAPI{ctrl: ctrl}
+	mock.recorder = &MockNetworkMappingsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkMappingsAPI) EXPECT() *MockNetworkMappingsAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateNetworkMapping mocks base method.
+func (m *MockNetworkMappingsAPI) CreateNetworkMapping(ctx context.Context, mapping dnssdk.NetworkMappingRequest) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNetworkMapping", ctx, mapping)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNetworkMapping indicates an expected call of CreateNetworkMapping.
+func (mr *MockNetworkMappingsAPIMockRecorder) CreateNetworkMapping(ctx, mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetworkMapping", reflect.TypeOf((*MockNetworkMappingsAPI)(nil).CreateNetworkMapping), ctx, mapping)
+}
+
+// DeleteNetworkMapping mocks base method.
+func (m *MockNetworkMappingsAPI) DeleteNetworkMapping(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNetworkMapping", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNetworkMapping indicates an expected call of DeleteNetworkMapping.
+func (mr *MockNetworkMappingsAPIMockRecorder) DeleteNetworkMapping(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetworkMapping", reflect.TypeOf((*MockNetworkMappingsAPI)(nil).DeleteNetworkMapping), ctx, id)
+}
+
+// GetNetworkMapping mocks base method.
+func (m *MockNetworkMappingsAPI) GetNetworkMapping(ctx context.Context, id uint64) (*dnssdk.NetworkMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkMapping", ctx, id)
+	ret0, _ := ret[0].(*dnssdk.NetworkMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkMapping indicates an expected call of GetNetworkMapping.
+func (mr *MockNetworkMappingsAPIMockRecorder) GetNetworkMapping(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkMapping", reflect.TypeOf((*MockNetworkMappingsAPI)(nil).GetNetworkMapping), ctx, id)
+}
+
+// GetNetworkMappingByName mocks base method.
+func (m *MockNetworkMappingsAPI) GetNetworkMappingByName(ctx context.Context, name string) (*dnssdk.NetworkMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkMappingByName", ctx, name)
+	ret0, _ := ret[0].(*dnssdk.NetworkMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkMappingByName indicates an expected call of GetNetworkMappingByName.
+func (mr *MockNetworkMappingsAPIMockRecorder) GetNetworkMappingByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkMappingByName", reflect.TypeOf((*MockNetworkMappingsAPI)(nil).GetNetworkMappingByName), ctx, name)
+}
+
+// ListNetworkMappings mocks base method.
+func (m *MockNetworkMappingsAPI) ListNetworkMappings(ctx context.Context, params dnssdk.NetworkMappingsParams) (*dnssdk.ListNetworkMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkMappings", ctx, params)
+	ret0, _ := ret[0].(*dnssdk.ListNetworkMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkMappings indicates an expected call of ListNetworkMappings.
+func (mr *MockNetworkMappingsAPIMockRecorder) ListNetworkMappings(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkMappings", reflect.TypeOf((*MockNetworkMappingsAPI)(nil).ListNetworkMappings), ctx, params)
+}
+
+// UpdateNetworkMapping mocks base method.
+func (m *MockNetworkMappingsAPI) UpdateNetworkMapping(ctx context.Context, id uint64, mapping dnssdk.NetworkMappingRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNetworkMapping", ctx, id, mapping)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNetworkMapping indicates an expected call of UpdateNetworkMapping.
+func (mr *MockNetworkMappingsAPIMockRecorder) UpdateNetworkMapping(ctx, id, mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNetworkMapping", reflect.TypeOf((*MockNetworkMappingsAPI)(nil).UpdateNetworkMapping), ctx, id, mapping)
+}
+
+// MockSecondaryZonesAPI is a mock of SecondaryZonesAPI interface.
+type MockSecondaryZonesAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecondaryZonesAPIMockRecorder
+}
+
+// MockSecondaryZonesAPIMockRecorder is the mock recorder for MockSecondaryZonesAPI.
+type MockSecondaryZonesAPIMockRecorder struct {
+	mock *MockSecondaryZonesAPI
+}
+
+// NewMockSecondaryZonesAPI creates a new mock instance.
+func NewMockSecondaryZonesAPI(ctrl *gomock.Controller) *MockSecondaryZonesAPI {
+	mock := &MockSecondaryZonesAPI{ctrl: ctrl}
+	mock.recorder = &MockSecondaryZonesAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecondaryZonesAPI) EXPECT() *MockSecondaryZonesAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateSecondaryZone mocks base method.
+func (m *MockSecondaryZonesAPI) CreateSecondaryZone(ctx context.Context, zone dnssdk.AddSecondaryZone) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecondaryZone", ctx, zone)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecondaryZone indicates an expected call of CreateSecondaryZone.
+func (mr *MockSecondaryZonesAPIMockRecorder) CreateSecondaryZone(ctx, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecondaryZone", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).CreateSecondaryZone), ctx, zone)
+}
+
+// CreateTSIGKey mocks base method.
+func (m *MockSecondaryZonesAPI) CreateTSIGKey(ctx context.Context, key dnssdk.TSIGKey) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTSIGKey", ctx, key)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTSIGKey indicates an expected call of CreateTSIGKey.
+func (mr *MockSecondaryZonesAPIMockRecorder) CreateTSIGKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTSIGKey", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).CreateTSIGKey), ctx, key)
+}
+
+// DeleteSecondaryZone mocks base method.
+func (m *MockSecondaryZonesAPI) DeleteSecondaryZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecondaryZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecondaryZone indicates an expected call of DeleteSecondaryZone.
+func (mr *MockSecondaryZonesAPIMockRecorder) DeleteSecondaryZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecondaryZone", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).DeleteSecondaryZone), ctx, name)
+}
+
+// DeleteTSIGKey mocks base method.
+func (m *MockSecondaryZonesAPI) DeleteTSIGKey(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTSIGKey", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTSIGKey indicates an expected call of DeleteTSIGKey.
+func (mr *MockSecondaryZonesAPIMockRecorder) DeleteTSIGKey(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTSIGKey", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).DeleteTSIGKey), ctx, name)
+}
+
+// RetransferSecondaryZone mocks base method.
+func (m *MockSecondaryZonesAPI) RetransferSecondaryZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetransferSecondaryZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetransferSecondaryZone indicates an expected call of RetransferSecondaryZone.
+func (mr *MockSecondaryZonesAPIMockRecorder) RetransferSecondaryZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetransferSecondaryZone", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).RetransferSecondaryZone), ctx, name)
+}
+
+// SecondaryZone mocks base method.
+func (m *MockSecondaryZonesAPI) SecondaryZone(ctx context.Context, name string) (dnssdk.SecondaryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecondaryZone", ctx, name)
+	ret0, _ := ret[0].(dnssdk.SecondaryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecondaryZone indicates an expected call of SecondaryZone.
+func (mr *MockSecondaryZonesAPIMockRecorder) SecondaryZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecondaryZone", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).SecondaryZone), ctx, name)
+}
+
+// SecondaryZoneTransfer mocks base method.
+func (m *MockSecondaryZonesAPI) SecondaryZoneTransfer(ctx context.Context, name string) (dnssdk.TransferStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecondaryZoneTransfer", ctx, name)
+	ret0, _ := ret[0].(dnssdk.TransferStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecondaryZoneTransfer indicates an expected call of SecondaryZoneTransfer.
+func (mr *MockSecondaryZonesAPIMockRecorder) SecondaryZoneTransfer(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecondaryZoneTransfer", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).SecondaryZoneTransfer), ctx, name)
+}
+
+// TSIGKeys mocks base method.
+func (m *MockSecondaryZonesAPI) TSIGKeys(ctx context.Context) ([]dnssdk.TSIGKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TSIGKeys", ctx)
+	ret0, _ := ret[0].([]dnssdk.TSIGKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TSIGKeys indicates an expected call of TSIGKeys.
+func (mr *MockSecondaryZonesAPIMockRecorder) TSIGKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TSIGKeys", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).TSIGKeys), ctx)
+}
+
+// UpdateSecondaryZone mocks base method.
+func (m *MockSecondaryZonesAPI) UpdateSecondaryZone(ctx context.Context, name string, zone dnssdk.AddSecondaryZone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecondaryZone", ctx, name, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecondaryZone indicates an expected call of UpdateSecondaryZone.
+func (mr *MockSecondaryZonesAPIMockRecorder) UpdateSecondaryZone(ctx, name, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecondaryZone", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).UpdateSecondaryZone), ctx, name, zone)
+}
+
+// UpdateTSIGKey mocks base method.
+func (m *MockSecondaryZonesAPI) UpdateTSIGKey(ctx context.Context, name string, key dnssdk.TSIGKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTSIGKey", ctx, name, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTSIGKey indicates an expected call of UpdateTSIGKey.
+func (mr *MockSecondaryZonesAPIMockRecorder) UpdateTSIGKey(ctx, name, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTSIGKey", reflect.TypeOf((*MockSecondaryZonesAPI)(nil).UpdateTSIGKey), ctx, name, key)
+}
+
+// MockAPI is a mock of API interface.
+type MockAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIMockRecorder
+}
+
+// MockAPIMockRecorder is the mock recorder for MockAPI.
+type MockAPIMockRecorder struct {
+	mock *MockAPI
+}
+
+// NewMockAPI creates a new mock instance.
+func NewMockAPI(ctrl *gomock.Controller) *MockAPI {
+	mock := &MockAPI{ctrl: ctrl}
+	mock.recorder = &MockAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
+	return m.recorder
+}
+
+// AddZoneRRSet mocks base method.
+func (m *MockAPI) AddZoneRRSet(ctx context.Context, zone, recordName, recordType string, values []dnssdk.ResourceRecord, ttl int, opts ...dnssdk.AddZoneOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, zone, recordName, recordType, values, ttl}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddZoneRRSet", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddZoneRRSet indicates an expected call of AddZoneRRSet.
+func (mr *MockAPIMockRecorder) AddZoneRRSet(ctx, zone, recordName, recordType, values, ttl any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, zone, recordName, recordType, values, ttl}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddZoneRRSet", reflect.TypeOf((*MockAPI)(nil).AddZoneRRSet), varargs...)
+}
+
+// AllZones mocks base method.
+func (m *MockAPI) AllZones(ctx context.Context, nameFilters []string) ([]dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllZones", ctx, nameFilters)
+	ret0, _ := ret[0].([]dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllZones indicates an expected call of AllZones.
+func (mr *MockAPIMockRecorder) AllZones(ctx, nameFilters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllZones", reflect.TypeOf((*MockAPI)(nil).AllZones), ctx, nameFilters)
+}
+
+// AllZonesWithRecords mocks base method.
+func (m *MockAPI) AllZonesWithRecords(ctx context.Context, nameFilters []string) ([]dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllZonesWithRecords", ctx, nameFilters)
+	ret0, _ := ret[0].([]dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllZonesWithRecords indicates an expected call of AllZonesWithRecords.
+func (mr *MockAPIMockRecorder) AllZonesWithRecords(ctx, nameFilters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllZonesWithRecords", reflect.TypeOf((*MockAPI)(nil).AllZonesWithRecords), ctx, nameFilters)
+}
+
+// CreateNetworkMapping mocks base method.
+func (m *MockAPI) CreateNetworkMapping(ctx context.Context, mapping dnssdk.NetworkMappingRequest) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNetworkMapping", ctx, mapping)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNetworkMapping indicates an expected call of CreateNetworkMapping.
+func (mr *MockAPIMockRecorder) CreateNetworkMapping(ctx, mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetworkMapping", reflect.TypeOf((*MockAPI)(nil).CreateNetworkMapping), ctx, mapping)
+}
+
+// CreateRRSet mocks base method.
+func (m *MockAPI) CreateRRSet(ctx context.Context, zone, name, recordType string, record dnssdk.RRSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRRSet", ctx, zone, name, recordType, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRRSet indicates an expected call of CreateRRSet.
+func (mr *MockAPIMockRecorder) CreateRRSet(ctx, zone, name, recordType, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRRSet", reflect.TypeOf((*MockAPI)(nil).CreateRRSet), ctx, zone, name, recordType, record)
+}
+
+// CreateSecondaryZone mocks base method.
+func (m *MockAPI) CreateSecondaryZone(ctx context.Context, zone dnssdk.AddSecondaryZone) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecondaryZone", ctx, zone)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecondaryZone indicates an expected call of CreateSecondaryZone.
+func (mr *MockAPIMockRecorder) CreateSecondaryZone(ctx, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecondaryZone", reflect.TypeOf((*MockAPI)(nil).CreateSecondaryZone), ctx, zone)
+}
+
+// CreateTSIGKey mocks base method.
+func (m *MockAPI) CreateTSIGKey(ctx context.Context, key dnssdk.TSIGKey) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTSIGKey", ctx, key)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTSIGKey indicates an expected call of CreateTSIGKey.
+func (mr *MockAPIMockRecorder) CreateTSIGKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTSIGKey", reflect.TypeOf((*MockAPI)(nil).CreateTSIGKey), ctx, key)
+}
+
+// CreateZone mocks base method.
+func (m *MockAPI) CreateZone(ctx context.Context, addZone dnssdk.AddZone) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateZone", ctx, addZone)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateZone indicates an expected call of CreateZone.
+func (mr *MockAPIMockRecorder) CreateZone(ctx, addZone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZone", reflect.TypeOf((*MockAPI)(nil).CreateZone), ctx, addZone)
+}
+
+// DNSSecDS mocks base method.
+func (m *MockAPI) DNSSecDS(ctx context.Context, zone string) (dnssdk.DNSSecDS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DNSSecDS", ctx, zone)
+	ret0, _ := ret[0].(dnssdk.DNSSecDS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DNSSecDS indicates an expected call of DNSSecDS.
+func (mr *MockAPIMockRecorder) DNSSecDS(ctx, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DNSSecDS", reflect.TypeOf((*MockAPI)(nil).DNSSecDS), ctx, zone)
+}
+
+// DeleteNetworkMapping mocks base method.
+func (m *MockAPI) DeleteNetworkMapping(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNetworkMapping", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNetworkMapping indicates an expected call of DeleteNetworkMapping.
+func (mr *MockAPIMockRecorder) DeleteNetworkMapping(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetworkMapping", reflect.TypeOf((*MockAPI)(nil).DeleteNetworkMapping), ctx, id)
+}
+
+// DeleteRRSet mocks base method.
+func (m *MockAPI) DeleteRRSet(ctx context.Context, zone, name, recordType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRRSet", ctx, zone, name, recordType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRRSet indicates an expected call of DeleteRRSet.
+func (mr *MockAPIMockRecorder) DeleteRRSet(ctx, zone, name, recordType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRRSet", reflect.TypeOf((*MockAPI)(nil).DeleteRRSet), ctx, zone, name, recordType)
+}
+
+// DeleteRRSetRecord mocks base method.
+func (m *MockAPI) DeleteRRSetRecord(ctx context.Context, zone, name, recordType string, contents ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, zone, name, recordType}
+	for _, a := range contents {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRRSetRecord", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRRSetRecord indicates an expected call of DeleteRRSetRecord.
+func (mr *MockAPIMockRecorder) DeleteRRSetRecord(ctx, zone, name, recordType any, contents ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, zone, name, recordType}, contents...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRRSetRecord", reflect.TypeOf((*MockAPI)(nil).DeleteRRSetRecord), varargs...)
+}
+
+// DeleteSecondaryZone mocks base method.
+func (m *MockAPI) DeleteSecondaryZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecondaryZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecondaryZone indicates an expected call of DeleteSecondaryZone.
+func (mr *MockAPIMockRecorder) DeleteSecondaryZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecondaryZone", reflect.TypeOf((*MockAPI)(nil).DeleteSecondaryZone), ctx, name)
+}
+
+// DeleteTSIGKey mocks base method.
+func (m *MockAPI) DeleteTSIGKey(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTSIGKey", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTSIGKey indicates an expected call of DeleteTSIGKey.
+func (mr *MockAPIMockRecorder) DeleteTSIGKey(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTSIGKey", reflect.TypeOf((*MockAPI)(nil).DeleteTSIGKey), ctx, name)
+}
+
+// DeleteZone mocks base method.
+func (m *MockAPI) DeleteZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteZone indicates an expected call of DeleteZone.
+func (mr *MockAPIMockRecorder) DeleteZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockAPI)(nil).DeleteZone), ctx, name)
+}
+
+// DisableZone mocks base method.
+func (m *MockAPI) DisableZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableZone indicates an expected call of DisableZone.
+func (mr *MockAPIMockRecorder) DisableZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableZone", reflect.TypeOf((*MockAPI)(nil).DisableZone), ctx, name)
+}
+
+// EnableZone mocks base method.
+func (m *MockAPI) EnableZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableZone indicates an expected call of EnableZone.
+func (mr *MockAPIMockRecorder) EnableZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableZone", reflect.TypeOf((*MockAPI)(nil).EnableZone), ctx, name)
+}
+
+// GetNetworkMapping mocks base method.
+func (m *MockAPI) GetNetworkMapping(ctx context.Context, id uint64) (*dnssdk.NetworkMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkMapping", ctx, id)
+	ret0, _ := ret[0].(*dnssdk.NetworkMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkMapping indicates an expected call of GetNetworkMapping.
+func (mr *MockAPIMockRecorder) GetNetworkMapping(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkMapping", reflect.TypeOf((*MockAPI)(nil).GetNetworkMapping), ctx, id)
+}
+
+// GetNetworkMappingByName mocks base method.
+func (m *MockAPI) GetNetworkMappingByName(ctx context.Context, name string) (*dnssdk.NetworkMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkMappingByName", ctx, name)
+	ret0, _ := ret[0].(*dnssdk.NetworkMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkMappingByName indicates an expected call of GetNetworkMappingByName.
+func (mr *MockAPIMockRecorder) GetNetworkMappingByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkMappingByName", reflect.TypeOf((*MockAPI)(nil).GetNetworkMappingByName), ctx, name)
+}
+
+// ImportZone mocks base method.
+func (m *MockAPI) ImportZone(ctx context.Context, name, content string) (dnssdk.ImportZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportZone", ctx, name, content)
+	ret0, _ := ret[0].(dnssdk.ImportZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportZone indicates an expected call of ImportZone.
+func (mr *MockAPIMockRecorder) ImportZone(ctx, name, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportZone", reflect.TypeOf((*MockAPI)(nil).ImportZone), ctx, name, content)
+}
+
+// ListNetworkMappings mocks base method.
+func (m *MockAPI) ListNetworkMappings(ctx context.Context, params dnssdk.NetworkMappingsParams) (*dnssdk.ListNetworkMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkMappings", ctx, params)
+	ret0, _ := ret[0].(*dnssdk.ListNetworkMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkMappings indicates an expected call of ListNetworkMappings.
+func (mr *MockAPIMockRecorder) ListNetworkMappings(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkMappings", reflect.TypeOf((*MockAPI)(nil).ListNetworkMappings), ctx, params)
+}
+
+// RRSet mocks base method.
+func (m *MockAPI) RRSet(ctx context.Context, zone, name, recordType string, limit, offset int) (dnssdk.RRSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RRSet", ctx, zone, name, recordType, limit, offset)
+	ret0, _ := ret[0].(dnssdk.RRSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RRSet indicates an expected call of RRSet.
+func (mr *MockAPIMockRecorder) RRSet(ctx, zone, name, recordType, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RRSet", reflect.TypeOf((*MockAPI)(nil).RRSet), ctx, zone, name, recordType, limit, offset)
+}
+
+// RetransferSecondaryZone mocks base method.
+func (m *MockAPI) RetransferSecondaryZone(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetransferSecondaryZone", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetransferSecondaryZone indicates an expected call of RetransferSecondaryZone.
+func (mr *MockAPIMockRecorder) RetransferSecondaryZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetransferSecondaryZone", reflect.TypeOf((*MockAPI)(nil).RetransferSecondaryZone), ctx, name)
+}
+
+// SecondaryZone mocks base method.
+func (m *MockAPI) SecondaryZone(ctx context.Context, name string) (dnssdk.SecondaryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecondaryZone", ctx, name)
+	ret0, _ := ret[0].(dnssdk.SecondaryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecondaryZone indicates an expected call of SecondaryZone.
+func (mr *MockAPIMockRecorder) SecondaryZone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecondaryZone", reflect.TypeOf((*MockAPI)(nil).SecondaryZone), ctx, name)
+}
+
+// SecondaryZoneTransfer mocks base method.
+func (m *MockAPI) SecondaryZoneTransfer(ctx context.Context, name string) (dnssdk.TransferStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecondaryZoneTransfer", ctx, name)
+	ret0, _ := ret[0].(dnssdk.TransferStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecondaryZoneTransfer indicates an expected call of SecondaryZoneTransfer.
+func (mr *MockAPIMockRecorder) SecondaryZoneTransfer(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecondaryZoneTransfer", reflect.TypeOf((*MockAPI)(nil).SecondaryZoneTransfer), ctx, name)
+}
+
+// TSIGKeys mocks base method.
+func (m *MockAPI) TSIGKeys(ctx context.Context) ([]dnssdk.TSIGKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TSIGKeys", ctx)
+	ret0, _ := ret[0].([]dnssdk.TSIGKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TSIGKeys indicates an expected call of TSIGKeys.
+func (mr *MockAPIMockRecorder) TSIGKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TSIGKeys", reflect.TypeOf((*MockAPI)(nil).TSIGKeys), ctx)
+}
+
+// ToggleDnssec mocks base method.
+func (m *MockAPI) ToggleDnssec(ctx context.Context, zone string, enable bool) (dnssdk.DNSSecDS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleDnssec", ctx, zone, enable)
+	ret0, _ := ret[0].(dnssdk.DNSSecDS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleDnssec indicates an expected call of ToggleDnssec.
+func (mr *MockAPIMockRecorder) ToggleDnssec(ctx, zone, enable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleDnssec", reflect.TypeOf((*MockAPI)(nil).ToggleDnssec), ctx, zone, enable)
+}
+
+// UpdateNetworkMapping mocks base method.
+func (m *MockAPI) UpdateNetworkMapping(ctx context.Context, id uint64, mapping dnssdk.NetworkMappingRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNetworkMapping", ctx, id, mapping)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNetworkMapping indicates an expected call of UpdateNetworkMapping.
+func (mr *MockAPIMockRecorder) UpdateNetworkMapping(ctx, id, mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNetworkMapping", reflect.TypeOf((*MockAPI)(nil).UpdateNetworkMapping), ctx, id, mapping)
+}
+
+// UpdateRRSet mocks base method.
+func (m *MockAPI) UpdateRRSet(ctx context.Context, zone, name, recordType string, record dnssdk.RRSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRRSet", ctx, zone, name, recordType, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRRSet indicates an expected call of UpdateRRSet.
+func (mr *MockAPIMockRecorder) UpdateRRSet(ctx, zone, name, recordType, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRRSet", reflect.TypeOf((*MockAPI)(nil).UpdateRRSet), ctx, zone, name, recordType, record)
+}
+
+// UpdateSecondaryZone mocks base method.
+func (m *MockAPI) UpdateSecondaryZone(ctx context.Context, name string, zone dnssdk.AddSecondaryZone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecondaryZone", ctx, name, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecondaryZone indicates an expected call of UpdateSecondaryZone.
+func (mr *MockAPIMockRecorder) UpdateSecondaryZone(ctx, name, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecondaryZone", reflect.TypeOf((*MockAPI)(nil).UpdateSecondaryZone), ctx, name, zone)
+}
+
+// UpdateTSIGKey mocks base method.
+func (m *MockAPI) UpdateTSIGKey(ctx context.Context, name string, key dnssdk.TSIGKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTSIGKey", ctx, name, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTSIGKey indicates an expected call of UpdateTSIGKey.
+func (mr *MockAPIMockRecorder) UpdateTSIGKey(ctx, name, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTSIGKey", reflect.TypeOf((*MockAPI)(nil).UpdateTSIGKey), ctx, name, key)
+}
+
+// UpdateZone mocks base method.
+func (m *MockAPI) UpdateZone(ctx context.Context, name string, updateZone dnssdk.AddZone) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateZone", ctx, name, updateZone)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateZone indicates an expected call of UpdateZone.
+func (mr *MockAPIMockRecorder) UpdateZone(ctx, name, updateZone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateZone", reflect.TypeOf((*MockAPI)(nil).UpdateZone), ctx, name, updateZone)
+}
+
+// Zone mocks base method.
+func (m *MockAPI) Zone(ctx context.Context, name string) (dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Zone", ctx, name)
+	ret0, _ := ret[0].(dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Zone indicates an expected call of Zone.
+func (mr *MockAPIMockRecorder) Zone(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Zone", reflect.TypeOf((*MockAPI)(nil).Zone), ctx, name)
+}
+
+// ZoneNameservers mocks base method.
+func (m *MockAPI) ZoneNameservers(ctx context.Context, name string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZoneNameservers", ctx, name)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZoneNameservers indicates an expected call of ZoneNameservers.
+func (mr *MockAPIMockRecorder) ZoneNameservers(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZoneNameservers", reflect.TypeOf((*MockAPI)(nil).ZoneNameservers), ctx, name)
+}
+
+// ZoneRRSets mocks base method.
+func (m *MockAPI) ZoneRRSets(ctx context.Context, zone string) ([]dnssdk.ZoneRRSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZoneRRSets", ctx, zone)
+	ret0, _ := ret[0].([]dnssdk.ZoneRRSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZoneRRSets indicates an expected call of ZoneRRSets.
+func (mr *MockAPIMockRecorder) ZoneRRSets(ctx, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZoneRRSets", reflect.TypeOf((*MockAPI)(nil).ZoneRRSets), ctx, zone)
+}
+
+// Zones mocks base method.
+func (m *MockAPI) Zones(ctx context.Context, filters ...func(*dnssdk.ZonesFilter)) ([]dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Zones", varargs...)
+	ret0, _ := ret[0].([]dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Zones indicates an expected call of Zones.
+func (mr *MockAPIMockRecorder) Zones(ctx any, filters ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, filters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Zones", reflect.TypeOf((*MockAPI)(nil).Zones), varargs...)
+}
+
+// ZonesWithParam mocks base method.
+func (m *MockAPI) ZonesWithParam(ctx context.Context, param dnssdk.ZonesParam) (dnssdk.ListZones, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZonesWithParam", ctx, param)
+	ret0, _ := ret[0].(dnssdk.ListZones)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZonesWithParam indicates an expected call of ZonesWithParam.
+func (mr *MockAPIMockRecorder) ZonesWithParam(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZonesWithParam", reflect.TypeOf((*MockAPI)(nil).ZonesWithParam), ctx, param)
+}
+
+// ZonesWithRecords mocks base method.
+func (m *MockAPI) ZonesWithRecords(ctx context.Context, filters ...func(*dnssdk.ZonesFilter)) ([]dnssdk.Zone, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ZonesWithRecords", varargs...)
+	ret0, _ := ret[0].([]dnssdk.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZonesWithRecords indicates an expected call of ZonesWithRecords.
+func (mr *MockAPIMockRecorder) ZonesWithRecords(ctx any, filters ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, filters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZonesWithRecords", reflect.TypeOf((*MockAPI)(nil).ZonesWithRecords), varargs...)
+}
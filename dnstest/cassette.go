@@ -0,0 +1,247 @@
+// Package dnstest records DNS API interactions to files and replays them offline.
+package dnstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode of Cassette
+type Mode int
+
+const (
+	// ModeReplay serves recorded responses without network
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and records them with responses
+	ModeRecord
+)
+
+const redacted = "REDACTED"
+
+// ErrNoInteraction returned on replay of request which was not recorded
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+// Interaction recorded request with its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request part of Interaction, URL is stored without scheme and host
+type Request struct {
+	Method        string `json:"method"`
+	URL           string `json:"url"`
+	Authorization string `json:"authorization,omitempty"`
+	Body          string `json:"body,omitempty"`
+}
+
+// Response part of Interaction
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Cassette is http.RoundTripper which records or replays interactions.
+// Secrets are never written: Authorization header is redacted,
+// values registered by Normalize are stored as placeholders.
+type Cassette struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	replacements []replacement
+}
+
+type replacement struct {
+	actual      string
+	placeholder string
+}
+
+// NewRecorder records interactions sent by next, http.DefaultTransport when nil, call Save to write them to path
+func NewRecorder(path string, next http.RoundTripper) *Cassette {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Cassette{path: path, mode: ModeRecord, next: next}
+}
+
+// NewReplayer loads interactions from path, error wraps os.ErrNotExist when nothing was recorded
+func NewReplayer(path string) (*Cassette, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	c := &Cassette{path: path, mode: ModeReplay}
+	if err = json.Unmarshal(bs, &c.interactions); err != nil {
+		return nil, fmt.Errorf("decode cassette %s: %w", path, err)
+	}
+	c.used = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+// Mode of cassette
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// Normalize stores actual value as placeholder, e.g. random zone name.
+// On replay the same placeholder is registered with new actual value, so requests match and responses get it back.
+func (c *Cassette) Normalize(actual, placeholder string) {
+	if actual == "" || actual == placeholder {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.replacements = append(c.replacements, replacement{actual: actual, placeholder: placeholder})
+}
+
+// Save writes recorded interactions to path
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bs, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("create cassette dir: %w", err)
+	}
+	if err = os.WriteFile(c.path, append(bs, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip implementation of http.RoundTripper
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := Request{
+		Method: req.Method,
+		URL:    c.normalize(req.URL.RequestURI()),
+		Body:   c.normalize(string(body)),
+	}
+	if req.Header.Get("Authorization") != "" {
+		recorded.Authorization = redacted
+	}
+
+	if c.mode == ModeReplay {
+		return c.replay(req, recorded)
+	}
+
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        c.normalizeLocked(string(respBody)),
+		},
+	})
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay first not used interaction with the same request, order of parallel requests does not matter
+func (c *Cassette) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, in := range c.interactions {
+		if c.used[i] || in.Request.Method != recorded.Method || in.Request.URL != recorded.URL ||
+			!sameBody(in.Request.Body, recorded.Body) {
+			continue
+		}
+		c.used[i] = true
+		header := http.Header{}
+		if in.Response.ContentType != "" {
+			header.Set("Content-Type", in.Response.ContentType)
+		}
+		body := c.denormalizeLocked(in.Response.Body)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s %s", ErrNoInteraction, recorded.Method, recorded.URL, recorded.Body)
+}
+
+func (c *Cassette) normalize(s string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.normalizeLocked(s)
+}
+
+func (c *Cassette) normalizeLocked(s string) string {
+	for _, r := range c.replacements {
+		s = strings.ReplaceAll(s, r.actual, r.placeholder)
+	}
+	return s
+}
+
+func (c *Cassette) denormalizeLocked(s string) string {
+	for _, r := range c.replacements {
+		s = strings.ReplaceAll(s, r.placeholder, r.actual)
+	}
+	return s
+}
+
+// sameBody compares json bodies semantically, other bodies as is
+func sameBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
@@ -0,0 +1,84 @@
+package dnstest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette_RecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		rw.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodPost {
+			_, _ = rw.Write([]byte(`{"id":1}`))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"error":"zone ` + strings.TrimPrefix(req.URL.Path, "/v2/zones/") + ` not found"}` + string(body)))
+	}))
+	t.Cleanup(server.Close)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	// record
+	recorder := NewRecorder(path, nil)
+	recorder.Normalize("qwertyuiop", "rand000001")
+	client := &http.Client{Transport: recorder}
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/v2/zones", strings.NewReader(`{"name": "qwertyuiop.com"}`))
+	req.Header.Set("Authorization", "APIKey secret")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	resp, err = client.Get(server.URL + "/v2/zones/qwertyuiop.com")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.NoError(t, recorder.Save())
+
+	saved, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(saved), "secret")
+	assert.NotContains(t, string(saved), "qwertyuiop")
+	assert.Contains(t, string(saved), "rand000001.com")
+	assert.Contains(t, string(saved), `"authorization": "REDACTED"`)
+
+	// replay with another random value in reverse order
+	replayer, err := NewReplayer(path)
+	require.NoError(t, err)
+	assert.Equal(t, ModeReplay, replayer.Mode())
+	replayer.Normalize("asdfghjklz", "rand000001")
+	client = &http.Client{Transport: replayer}
+
+	resp, err = client.Get("http://offline.invalid/v2/zones/asdfghjklz.com")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, `{"error":"zone asdfghjklz.com not found"}`, string(body))
+
+	resp, err = client.Post("http://offline.invalid/v2/zones", "application/json",
+		strings.NewReader(`{"name":"asdfghjklz.com"}`))
+	require.NoError(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"id":1}`, string(body))
+
+	// every interaction is replayed once
+	_, err = client.Post("http://offline.invalid/v2/zones", "application/json",
+		strings.NewReader(`{"name":"asdfghjklz.com"}`))
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
+
+func TestNewReplayer_notRecorded(t *testing.T) {
+	_, err := NewReplayer(filepath.Join(t.TempDir(), "missing.json"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}